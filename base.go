@@ -0,0 +1,200 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// basePrefixes are the prefixes Go gives numbers in some bases.
+var basePrefixes = map[int]string{2: "0b", 8: "0o", 16: "0x"}
+
+// parseBase parses the integer s in base, where s may carry the prefix
+// Go gives numbers in that base, such as "0x" for 16, with or without its
+// zero. The letter is never a digit of that base, so "x1F" reads as "1F".
+func (c *Caster) parseBase(s string, base int) (neg bool, digits string, err error) {
+	if base < 2 || base > 36 {
+		return false, "", fmt.Errorf("invalid base %d", base)
+	}
+	s = strings.TrimSpace(c.ascii(s))
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg, s = true, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if p := basePrefixes[base]; p != "" {
+		if len(s) > len(p) && strings.EqualFold(s[:len(p)], p) {
+			s = s[len(p):]
+		} else if len(s) > 1 && strings.EqualFold(s[:1], p[1:]) {
+			s = s[1:]
+		}
+	}
+	return neg, s, nil
+}
+
+// Int64BaseE casts an interface to an int64 type, reading strings in
+// base, which lies between 2 and 36, such as "1F" or "0x1F" in base 16.
+func Int64BaseE(i interface{}, base int) (int64, error) {
+	return std.Int64BaseE(i, base)
+}
+
+// Int64BaseE casts an interface to an int64 type, reading strings in
+// base, which lies between 2 and 36, such as "1F" or "0x1F" in base 16.
+func (c *Caster) Int64BaseE(i interface{}, base int) (int64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return 0, c.nilE("int64")
+	}
+
+	s, ok := i.(string)
+	if !ok {
+		return c.Int64E(i)
+	}
+	neg, digits, err := c.parseBase(s, base)
+	if err != nil {
+		return 0, c.castEf(i, "int64", "%w", err)
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	v, err := strconv.ParseInt(digits, base, 64)
+	if err != nil {
+		return 0, c.parseE(i, "int64", err)
+	}
+	return v, nil
+}
+
+// Uint64BaseE casts an interface to a uint64 type, reading strings in
+// base, which lies between 2 and 36, such as "1F" or "0x1F" in base 16.
+func Uint64BaseE(i interface{}, base int) (uint64, error) {
+	return std.Uint64BaseE(i, base)
+}
+
+// Uint64BaseE casts an interface to a uint64 type, reading strings in
+// base, which lies between 2 and 36, such as "1F" or "0x1F" in base 16.
+func (c *Caster) Uint64BaseE(i interface{}, base int) (uint64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return 0, c.nilE("uint64")
+	}
+
+	s, ok := i.(string)
+	if !ok {
+		return c.Uint64E(i)
+	}
+	neg, digits, err := c.parseBase(s, base)
+	if err == nil && neg {
+		err = errNegativeNotAllowed
+	}
+	if err != nil {
+		return 0, c.castEf(i, "uint64", "%w", err)
+	}
+	v, err := strconv.ParseUint(digits, base, 64)
+	if err != nil {
+		return 0, c.parseE(i, "uint64", err)
+	}
+	return v, nil
+}
+
+// IntFormat describes how to write integers in text.
+type IntFormat struct {
+	Base   int  // base between 2 and 36, 10 when zero
+	Prefix bool // start with "0b", "0o" or "0x" in base 2, 8 or 16
+	Upper  bool // write digits above 9 in upper case
+	Width  int  // pad with zeros to at least that many digits
+}
+
+// FormatIntE casts an interface to an integer and writes it in f.
+func FormatIntE(i interface{}, f IntFormat) (string, error) {
+	return std.FormatIntE(i, f)
+}
+
+// FormatIntE casts an interface to an integer and writes it in f.
+func (c *Caster) FormatIntE(i interface{}, f IntFormat) (string, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return "", c.nilE("string")
+	}
+	if f.Base != 0 && (f.Base < 2 || f.Base > 36) {
+		return "", c.castEf(i, "string", "invalid base %d", f.Base)
+	}
+
+	if s, ok := f.format(i); ok {
+		return s, nil
+	}
+	switch i.(type) {
+	case uint, uint64, uint32, uint16, uint8:
+	default:
+		if v, err := c.Int64E(i); err == nil {
+			s, _ := f.format(v)
+			return s, nil
+		}
+	}
+	v, err := c.Uint64E(i)
+	if err != nil {
+		return "", err
+	}
+	s, _ := f.format(v)
+	return s, nil
+}
+
+// format writes i in f when it is an integer.
+func (f IntFormat) format(i interface{}) (string, bool) {
+	base := f.Base
+	switch {
+	case base == 0:
+		base = 10
+	case base < 2 || base > 36:
+		return "", false
+	}
+
+	var s string
+	switch v := i.(type) {
+	case int:
+		s = strconv.FormatInt(int64(v), base)
+	case int64:
+		s = strconv.FormatInt(v, base)
+	case int32:
+		s = strconv.FormatInt(int64(v), base)
+	case int16:
+		s = strconv.FormatInt(int64(v), base)
+	case int8:
+		s = strconv.FormatInt(int64(v), base)
+	case uint:
+		s = strconv.FormatUint(uint64(v), base)
+	case uint64:
+		s = strconv.FormatUint(v, base)
+	case uint32:
+		s = strconv.FormatUint(uint64(v), base)
+	case uint16:
+		s = strconv.FormatUint(uint64(v), base)
+	case uint8:
+		s = strconv.FormatUint(uint64(v), base)
+	default:
+		return "", false
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	if f.Upper {
+		s = strings.ToUpper(s)
+	}
+	if n := f.Width - len(s); n > 0 {
+		s = strings.Repeat("0", n) + s
+	}
+	if f.Prefix {
+		s = basePrefixes[base] + s
+	}
+	return sign + s, true
+}
@@ -0,0 +1,142 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt64BaseE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		base   int
+		expect int64
+		iserr  bool
+	}{
+		{"1F", 16, 31, false},
+		{"0x1F", 16, 31, false},
+		{"0X1f", 16, 31, false},
+		{"-ff", 16, -255, false},
+		{"777", 8, 511, false},
+		{"0o777", 8, 511, false},
+		{"1010", 2, 10, false},
+		{"0b1010", 2, 10, false},
+		{"b1010", 2, 10, false},
+		{"-o17", 8, -15, false},
+		{"X1f", 16, 31, false},
+		{"b1", 16, 177, false},
+		{"+z", 36, 35, false},
+		{"010", 10, 10, false},
+		{42, 16, 42, false},
+		// errors
+		{"0x1F", 10, 0, true},
+		{"8", 8, 0, true},
+		{"12", 1, 0, true},
+		{"12", 37, 0, true},
+		{"0b", 2, 0, true},
+		{"b", 2, 0, true},
+		{"x1F", 10, 0, true},
+		{"8000000000000000", 16, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := Int64BaseE(test.input, test.base)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	u, err := Uint64BaseE("ffffffffffffffff", 16)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(math.MaxUint64), u)
+	_, err = Uint64BaseE("-1", 16)
+	assert.Error(t, err)
+}
+
+func TestNoBasePrefix(t *testing.T) {
+	c := &Caster{NoBasePrefix: true}
+
+	v, err := c.IntE("010")
+	assert.NoError(t, err)
+	assert.Equal(t, 10, v)
+
+	v, err = c.IntE("1_000")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000, v)
+
+	u, err := c.Uint8E("0099")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(99), u)
+
+	_, err = c.IntE("0x1F")
+	assert.Error(t, err)
+
+	v, err = IntE("010")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, v)
+}
+
+func TestFormatIntE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		format IntFormat
+		expect string
+		iserr  bool
+	}{
+		{255, IntFormat{Base: 16}, "ff", false},
+		{255, IntFormat{Base: 16, Upper: true, Prefix: true}, "0xFF", false},
+		{-255, IntFormat{Base: 16, Prefix: true, Width: 4}, "-0x00ff", false},
+		{uint8(5), IntFormat{Base: 2, Prefix: true, Width: 8}, "0b00000101", false},
+		{uint64(math.MaxUint64), IntFormat{}, "18446744073709551615", false},
+		{8, IntFormat{Base: 8, Prefix: true}, "0o10", false},
+		{35, IntFormat{Base: 36}, "z", false},
+		{42, IntFormat{Width: 6}, "000042", false},
+		{42, IntFormat{Base: 10, Prefix: true}, "42", false},
+		{"0x1F", IntFormat{Base: 2}, "11111", false},
+		{"18446744073709551615", IntFormat{Base: 16}, "ffffffffffffffff", false},
+		{2.0, IntFormat{Base: 16}, "2", false},
+		// errors
+		{42, IntFormat{Base: 40}, "", true},
+		{"abc", IntFormat{}, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := FormatIntE(test.input, test.format)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestCasterIntFormat(t *testing.T) {
+	c := &Caster{IntFormat: &IntFormat{Base: 16, Prefix: true}, NumberFormat: &NumberFormat{MaxFraction: 1}}
+
+	s, err := c.StringE(255)
+	assert.NoError(t, err)
+	assert.Equal(t, "0xff", s)
+
+	s, err = c.StringE(1.25)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.3", s)
+
+	s, err = (&Caster{IntFormat: &IntFormat{Base: 99}}).StringE(255)
+	assert.NoError(t, err)
+	assert.Equal(t, "255", s)
+}
@@ -0,0 +1,166 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// NilPolicy selects what casting nil, or a nil pointer, produces.
+type NilPolicy int
+
+const (
+	// NilZero casts nil to the zero value of the target type without an
+	// error: 0, "", false, a nil map or a nil slice.
+	NilZero NilPolicy = iota
+	// NilError fails to cast nil with an error wrapping ErrNil.
+	NilError
+)
+
+// ErrNil is wrapped by the errors of a Caster using NilError when it is
+// given nil.
+var ErrNil = errors.New("unable to cast nil")
+
+// Caster holds the options that tune how values are cast. The zero value
+// casts exactly like the package-level functions, which use a shared zero
+// Caster.
+type Caster struct {
+	// TimeEpoch selects how TimeE interprets numeric input.
+	TimeEpoch Epoch
+
+	// BoolWords adds words BoolE understands, such as "да" or "нет", on top
+	// of its built-in ones. Words are matched ignoring case and take
+	// precedence over the built-in ones.
+	BoolWords map[string]bool
+
+	// Truthiness selects the rules TruthyE follows.
+	Truthiness Truthiness
+
+	// Separators lists the runes that split a string into the elements of
+	// a slice, where white space stands for any run of white space. Empty
+	// means ", ", commas and white space. Strings holding a JSON array are
+	// decoded instead.
+	Separators string
+
+	// PairSeparators lists the runes that split the pairs of a string such
+	// as "a=1,b=2" cast to a map, and KeyValueSeparators those that split a
+	// pair into its key and value. Empty means ",;" and "=:". Strings
+	// holding a JSON object are decoded instead.
+	PairSeparators     string
+	KeyValueSeparators string
+
+	// MaxRangeLen caps how many numbers a range list may expand to. Zero
+	// means DefaultMaxRangeLen.
+	MaxRangeLen int
+
+	// MaxDepth caps how deeply nested a value NormalizeE accepts, and the
+	// JSON strings parsed into slices and maps. Zero means DefaultMaxDepth,
+	// and a negative value means no limit.
+	MaxDepth int
+
+	// MaxInputLen caps the length in bytes of strings parsed into slices
+	// and maps, and MaxElements how many elements and entries they may
+	// hold in all. Zero means DefaultMaxInputLen and DefaultMaxElements,
+	// and a negative value means no limit. Breaking a limit fails with a
+	// *LimitError.
+	MaxInputLen int
+	MaxElements int
+
+	// Numbers selects the type NormalizeE gives the numbers it meets.
+	Numbers NumberForm
+
+	// Locale selects how numeric casters read numbers in strings.
+	Locale Locale
+
+	// NoBasePrefix makes integer casters read every string in base 10, so
+	// that "010" is 10 rather than 8 and "0x1F" fails.
+	NoBasePrefix bool
+
+	// UnicodeDigits makes numeric, duration and time casters read the
+	// Unicode decimal digits of any script, minus and plus sign variants,
+	// full-width forms and white space in strings as their ASCII forms.
+	UnicodeDigits bool
+
+	// SIBinary makes the SI prefixes from k up count in powers of 1024
+	// rather than 1000 in SIInt64E and SIFloat64E, and FormatSIE write IEC
+	// binary prefixes such as "Ki".
+	SIBinary bool
+
+	// NumberFormat, when set, is how StringE writes numbers, and
+	// IntFormat how it writes integers, taking precedence.
+	NumberFormat *NumberFormat
+	IntFormat    *IntFormat
+
+	// NonFinite selects what float and integer casters do with NaN and
+	// infinities, and with floats too large for their target type. Sentinel
+	// is the value NonFiniteSentinel puts in their place, and should fit
+	// every type it may be cast to.
+	NonFinite NonFinite
+	Sentinel  float64
+
+	// Exact makes numeric casters fail with an error wrapping ErrInexact
+	// rather than round a number their target type cannot hold exactly:
+	// a float64 narrowed to a float32, an integer too large for the
+	// mantissa of a float, or a float with a fraction, or out of range,
+	// cast to an integer or a Duration. Strings cast to a float32 are read as a float64
+	// first, so that "0.1" fails too.
+	Exact bool
+
+	// CollectErrors makes slice and map casters go on past a failed element
+	// and report every failure as ElementErrors, rather than stopping at
+	// the first one as an *ElementError.
+	CollectErrors bool
+
+	// Lenient makes slice and map casters keep the zero value for elements
+	// that fail to cast instead of returning an error. It takes precedence
+	// over CollectErrors.
+	Lenient bool
+
+	// MaxValueLen caps how many bytes of an input value error messages
+	// show, cutting longer ones short with "...". Zero means
+	// DefaultMaxValueLen and a negative value means no limit.
+	MaxValueLen int
+
+	// Redact reports whether the value under a map key or struct field of
+	// the given name must be hidden in error messages, as by RedactKeys.
+	Redact func(key string) bool
+
+	// OmitValues leaves input values out of error messages, which name
+	// only their type.
+	OmitValues bool
+
+	// Nil selects what every caster but TruthyE does with nil and nil
+	// pointers.
+	Nil NilPolicy
+}
+
+// std is the Caster behind the package-level functions.
+var std = &Caster{}
+
+// nilE returns the error c.Nil calls for when casting nil to the named type.
+func (c *Caster) nilE(to string) error {
+	if c.Nil == NilError {
+		return fmt.Errorf("%w to %s", ErrNil, to)
+	}
+	return nil
+}
+
+// isNil reports whether i is nil or a chain of pointers ending in nil.
+func isNil(i interface{}) bool {
+	if i == nil {
+		return true
+	}
+	v := reflect.ValueOf(i)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return true
+		}
+		v = v.Elem()
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNilPolicy(t *testing.T) {
+	var nilInt *int
+	var nilStringer *foo
+
+	casters := []func(c *Caster, i interface{}) (interface{}, error){
+		func(c *Caster, i interface{}) (interface{}, error) { return c.BoolE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.TimeE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.IntE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.Int8E(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.Uint64E(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.StringE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapIntE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapStringSliceE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.SliceE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.BoolSliceE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.StringSliceE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.DurationSliceE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.DateE(i) },
+		func(c *Caster, i interface{}) (interface{}, error) { return c.EpochTimeE(i, EpochOLE) },
+	}
+
+	for n, cast := range casters {
+		for _, input := range []interface{}{nil, nilInt, &nilInt, nilStringer} {
+			errmsg := fmt.Sprintf("caster = %d, input = %#v", n, input) // assert helper message
+
+			_, err := cast(&Caster{}, input)
+			assert.NoError(t, err, errmsg)
+
+			_, err = cast(&Caster{Nil: NilError}, input)
+			assert.True(t, errors.Is(err, ErrNil), errmsg)
+		}
+	}
+
+	v, err := (&Caster{}).StringMapIntE(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+
+	tm, err := (&Caster{}).TimeE(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Time{}, tm)
+
+	_, err = (&Caster{Nil: NilError}).IntE(nil)
+	assert.EqualError(t, err, "unable to cast nil to int")
+}
@@ -0,0 +1,302 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Date is a calendar date without a time of day or location.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// TimeOfDay is a wall clock time without a date or location.
+type TimeOfDay struct {
+	Hour       int
+	Minute     int
+	Second     int
+	Nanosecond int
+}
+
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006/01/02",
+	"20060102",
+	"02 Jan 2006",
+	"Jan 2, 2006",
+}
+
+var timeOfDayLayouts = []string{
+	"15:04:05",
+	"15:04",
+	"3:04:05PM",
+	"3:04:05 PM",
+	"3:04PM",
+	"3:04 PM",
+	"3PM",
+	"3 PM",
+}
+
+// DateOf returns the Date on which t falls in its own location.
+func DateOf(t time.Time) Date {
+	var d Date
+	d.Year, d.Month, d.Day = t.Date()
+	return d
+}
+
+// ParseDate parses a date such as "2006-01-02".
+func ParseDate(s string) (Date, error) {
+	t, err := parseDateWith(strings.TrimSpace(s), dateLayouts)
+	if err != nil {
+		return Date{}, err
+	}
+	return DateOf(t), nil
+}
+
+// String returns the date in "2006-01-02" form.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// IsZero reports whether d is the zero Date.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// IsValid reports whether d names a day that exists.
+func (d Date) IsValid() bool {
+	return DateOf(d.In(time.UTC)) == d
+}
+
+// In returns the time at midnight starting d in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// At returns the time at t on d in loc.
+func (d Date) At(t TimeOfDay, loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, t.Hour, t.Minute, t.Second, t.Nanosecond, loc)
+}
+
+// MarshalText implements encoding.TextMarshaler, and through it JSON. The
+// zero Date is written as "".
+func (d Date) MarshalText() ([]byte, error) {
+	if d.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(d.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, and through it JSON.
+// An empty text is the zero Date.
+func (d *Date) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		*d = Date{}
+		return nil
+	}
+	v, err := ParseDate(string(b))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// TimeOfDayOf returns the wall clock of t in its own location.
+func TimeOfDayOf(t time.Time) TimeOfDay {
+	var tod TimeOfDay
+	tod.Hour, tod.Minute, tod.Second = t.Clock()
+	tod.Nanosecond = t.Nanosecond()
+	return tod
+}
+
+// ParseTimeOfDay parses a wall clock time such as "09:30", "09:30:15.5"
+// or "9:30 PM".
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := parseDateWith(strings.TrimSpace(s), timeOfDayLayouts)
+	if err != nil {
+		return TimeOfDay{}, fmt.Errorf("unable to parse time of day: %s", s)
+	}
+	return TimeOfDayOf(t), nil
+}
+
+// String returns the time in "15:04:05" form, followed by as many
+// fractional second digits as needed.
+func (t TimeOfDay) String() string {
+	s := fmt.Sprintf("%02d:%02d:%02d", t.Hour, t.Minute, t.Second)
+	if t.Nanosecond == 0 {
+		return s
+	}
+	return s + strings.TrimRight(fmt.Sprintf(".%09d", t.Nanosecond), "0")
+}
+
+// IsValid reports whether every field of t is within its range.
+func (t TimeOfDay) IsValid() bool {
+	return t.Hour >= 0 && t.Hour < 24 &&
+		t.Minute >= 0 && t.Minute < 60 &&
+		t.Second >= 0 && t.Second < 60 &&
+		t.Nanosecond >= 0 && t.Nanosecond < 1e9
+}
+
+// Duration returns the time elapsed since midnight.
+func (t TimeOfDay) Duration() time.Duration {
+	return time.Duration(t.Hour)*time.Hour +
+		time.Duration(t.Minute)*time.Minute +
+		time.Duration(t.Second)*time.Second +
+		time.Duration(t.Nanosecond)
+}
+
+// On returns the time at t on d in loc.
+func (t TimeOfDay) On(d Date, loc *time.Location) time.Time {
+	return d.At(t, loc)
+}
+
+// MarshalText implements encoding.TextMarshaler, and through it JSON.
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, and through it JSON.
+func (t *TimeOfDay) UnmarshalText(b []byte) error {
+	v, err := ParseTimeOfDay(string(b))
+	if err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// DateE casts an interface to a Date type. Maps are read through their
+// "year", "month" and "day" keys; other strings fall back to StringToDate
+// and keep the date as written, whatever its zone.
+func DateE(i interface{}) (Date, error) {
+	return std.DateE(i)
+}
+
+// DateE casts an interface to a Date type. Maps are read through their
+// "year", "month" and "day" keys; other strings fall back to StringToDate
+// and keep the date as written, whatever its zone.
+func (c *Caster) DateE(i interface{}) (Date, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return Date{}, c.nilE("Date")
+	}
+
+	switch v := i.(type) {
+	case Date:
+		return v, nil
+	case time.Time:
+		return DateOf(v), nil
+	case string:
+		s := c.ascii(v)
+		if d, err := ParseDate(s); err == nil {
+			return d, nil
+		}
+		t, err := StringToDate(s)
+		if err != nil {
+			return Date{}, c.castE(i, "Date")
+		}
+		return DateOf(t), nil
+	case []byte:
+		return c.DateE(string(v))
+	}
+
+	m, err := c.StringMapE(i)
+	if err != nil {
+		return Date{}, c.castE(i, "Date")
+	}
+	var f [3]int
+	for n, key := range []string{"year", "month", "day"} {
+		if f[n], err = c.civilField(m, key, true); err != nil {
+			return Date{}, c.castEf(i, "Date", "%w", err)
+		}
+	}
+	d := Date{f[0], time.Month(f[1]), f[2]}
+	if !d.IsValid() {
+		return Date{}, c.castEf(i, "Date", "%s does not exist", d)
+	}
+	return d, nil
+}
+
+// TimeOfDayE casts an interface to a TimeOfDay type. A time.Duration is
+// read as the time elapsed since midnight, and maps through their "hour",
+// "minute", "second" and "nanosecond" keys, of which only "hour" is
+// required.
+func TimeOfDayE(i interface{}) (TimeOfDay, error) {
+	return std.TimeOfDayE(i)
+}
+
+// TimeOfDayE casts an interface to a TimeOfDay type. A time.Duration is
+// read as the time elapsed since midnight, and maps through their "hour",
+// "minute", "second" and "nanosecond" keys, of which only "hour" is
+// required.
+func (c *Caster) TimeOfDayE(i interface{}) (TimeOfDay, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return TimeOfDay{}, c.nilE("TimeOfDay")
+	}
+
+	switch v := i.(type) {
+	case TimeOfDay:
+		return v, nil
+	case time.Time:
+		return TimeOfDayOf(v), nil
+	case time.Duration:
+		if v < 0 || v >= day {
+			return TimeOfDay{}, c.castEf(i, "TimeOfDay", "out of range")
+		}
+		return TimeOfDayOf(unixEpoch.Add(v)), nil
+	case string:
+		t, err := ParseTimeOfDay(c.ascii(v))
+		if err != nil {
+			return TimeOfDay{}, c.castE(i, "TimeOfDay")
+		}
+		return t, nil
+	case []byte:
+		return c.TimeOfDayE(string(v))
+	}
+
+	m, err := c.StringMapE(i)
+	if err != nil {
+		return TimeOfDay{}, c.castE(i, "TimeOfDay")
+	}
+	var f [4]int
+	for n, key := range []string{"hour", "minute", "second", "nanosecond"} {
+		if f[n], err = c.civilField(m, key, n == 0); err != nil {
+			return TimeOfDay{}, c.castEf(i, "TimeOfDay", "%w", err)
+		}
+	}
+	t := TimeOfDay{f[0], f[1], f[2], f[3]}
+	if !t.IsValid() {
+		return TimeOfDay{}, c.castEf(i, "TimeOfDay", "%s is out of range", t)
+	}
+	return t, nil
+}
+
+// civilField looks up key in m, ignoring case, and casts it to an int. A
+// missing key reads as zero unless it is required.
+func (c *Caster) civilField(m map[string]interface{}, key string, required bool) (int, error) {
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			n, err := c.IntE(v)
+			if err != nil {
+				return 0, fmt.Errorf("bad %q: %s", key, err)
+			}
+			return n, nil
+		}
+	}
+	if required {
+		return 0, fmt.Errorf("missing %q", key)
+	}
+	return 0, nil
+}
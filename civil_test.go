@@ -0,0 +1,140 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateE(t *testing.T) {
+	moscow := time.FixedZone("MSK", 3*3600)
+	d := Date{2006, time.January, 2}
+
+	tests := []struct {
+		input  interface{}
+		expect Date
+		iserr  bool
+	}{
+		{"2006-01-02", d, false},
+		{" 2006/01/02 ", d, false},
+		{"20060102", d, false},
+		{"Jan 2, 2006", d, false},
+		{[]byte("2006-01-02"), d, false},
+		{"2006-01-02T01:00:00+03:00", d, false},
+		{time.Date(2006, 1, 2, 1, 0, 0, 0, moscow), d, false},
+		{d, d, false},
+		{&d, d, false},
+		{map[string]interface{}{"year": 2006, "month": "1", "day": 2}, d, false},
+		{map[interface{}]interface{}{"Year": 2006, "Month": 1, "Day": 2}, d, false},
+		// errors
+		{"2006-02-30", Date{}, true},
+		{"test", Date{}, true},
+		{map[string]interface{}{"year": 2006, "month": 2, "day": 30}, Date{}, true},
+		{map[string]interface{}{"year": 2006, "month": 2}, Date{}, true},
+		{map[string]interface{}{"year": 2006, "month": "x", "day": 1}, Date{}, true},
+		{testing.T{}, Date{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := DateE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestTimeOfDayE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect TimeOfDay
+		iserr  bool
+	}{
+		{"09:30", TimeOfDay{9, 30, 0, 0}, false},
+		{"09:30:15", TimeOfDay{9, 30, 15, 0}, false},
+		{"09:30:15.25", TimeOfDay{9, 30, 15, 250000000}, false},
+		{"9:30 PM", TimeOfDay{21, 30, 0, 0}, false},
+		{"9PM", TimeOfDay{21, 0, 0, 0}, false},
+		{time.Date(2006, 1, 2, 9, 30, 0, 0, time.UTC), TimeOfDay{9, 30, 0, 0}, false},
+		{90 * time.Minute, TimeOfDay{1, 30, 0, 0}, false},
+		{map[string]interface{}{"hour": 9, "minute": 30}, TimeOfDay{9, 30, 0, 0}, false},
+		// errors
+		{"25:00", TimeOfDay{}, true},
+		{"test", TimeOfDay{}, true},
+		{25 * time.Hour, TimeOfDay{}, true},
+		{map[string]interface{}{"minute": 30}, TimeOfDay{}, true},
+		{map[string]interface{}{"hour": 9, "minute": 60}, TimeOfDay{}, true},
+		{map[string]interface{}{"hour": 9, "minute": "x"}, TimeOfDay{}, true},
+		{testing.T{}, TimeOfDay{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := TimeOfDayE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestCivilConversions(t *testing.T) {
+	d := Date{2006, time.January, 2}
+	tod := TimeOfDay{9, 30, 15, 500000000}
+
+	assert.Equal(t, "2006-01-02", d.String())
+	assert.Equal(t, "09:30:15.5", tod.String())
+	assert.Equal(t, "09:30:00", TimeOfDay{9, 30, 0, 0}.String())
+	assert.True(t, d.IsValid())
+	assert.False(t, Date{2006, time.February, 29}.IsValid())
+	assert.True(t, Date{}.IsZero())
+
+	loc := time.FixedZone("X", -5*3600)
+	assert.Equal(t, time.Date(2006, 1, 2, 0, 0, 0, 0, loc), d.In(loc))
+	assert.Equal(t, time.Date(2006, 1, 2, 9, 30, 15, 500000000, loc), d.At(tod, loc))
+	assert.Equal(t, d.At(tod, loc), tod.On(d, loc))
+	assert.Equal(t, 9*time.Hour+30*time.Minute+15500*time.Millisecond, tod.Duration())
+}
+
+func TestCivilJSON(t *testing.T) {
+	type record struct {
+		Day  Date      `json:"day"`
+		Time TimeOfDay `json:"time"`
+	}
+	in := record{Date{2006, time.January, 2}, TimeOfDay{9, 30, 0, 0}}
+
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"day":"2006-01-02","time":"09:30:00"}`, string(b))
+
+	var out record
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in, out)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"day":"tomorrow"}`), &out))
+
+	// The zero value survives a round trip.
+	b, err = json.Marshal(record{})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"day":"","time":"00:00:00"}`, string(b))
+	out = record{Day: Date{2006, time.January, 2}}
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, record{}, out)
+}
@@ -0,0 +1,254 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Epoch selects how a number is read as a point in time.
+type Epoch int
+
+const (
+	// EpochUnix counts seconds since 1970-01-01 UTC.
+	EpochUnix Epoch = iota
+	// EpochExcel1900 counts days in the Excel 1900 date system. Serial 1 is
+	// 1900-01-01 and serial 60 is the non-existent 1900-02-29 that Excel
+	// keeps for Lotus 1-2-3 compatibility.
+	EpochExcel1900
+	// EpochExcel1904 counts days since 1904-01-01, the Excel for Mac system.
+	EpochExcel1904
+	// EpochOLE counts days since 1899-12-30, the OLE Automation date.
+	EpochOLE
+	// EpochJulian is the Julian Day number, days since noon UTC on
+	// 4714-11-24 BC in the proleptic Gregorian calendar.
+	EpochJulian
+	// EpochTicks counts .NET ticks, 100ns intervals since 0001-01-01 UTC.
+	EpochTicks
+)
+
+var errExcelLeapDay = errors.New("serial 60 is the non-existent Excel date 1900-02-29")
+
+var (
+	unixEpoch      = time.Unix(0, 0).UTC()
+	oleEpoch       = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	excel1904Epoch = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+const (
+	day             = 24 * time.Hour
+	maxEpochDays    = 1e8
+	julianUnixEpoch = 2440587.5
+	ticksPerSecond  = 1e7
+	ticksUnixEpoch  = 621355968000000000
+)
+
+func (e Epoch) String() string {
+	switch e {
+	case EpochUnix:
+		return "Unix"
+	case EpochExcel1900:
+		return "Excel1900"
+	case EpochExcel1904:
+		return "Excel1904"
+	case EpochOLE:
+		return "OLE"
+	case EpochJulian:
+		return "Julian"
+	case EpochTicks:
+		return "Ticks"
+	default:
+		return fmt.Sprintf("Epoch(%d)", int(e))
+	}
+}
+
+// Time converts n, counted in the units of e, to a time.Time. Unix
+// timestamps are returned in the local time zone like time.Unix; the
+// other epochs carry no zone and are returned as UTC.
+func (e Epoch) Time(n float64) (time.Time, error) {
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return time.Time{}, fmt.Errorf("unable to cast %v to %s Time", n, e)
+	}
+
+	switch e {
+	case EpochUnix:
+		if math.Abs(n) > maxEpochDays*day.Seconds() {
+			return time.Time{}, fmt.Errorf("unable to cast %v to %s Time: out of range", n, e)
+		}
+		sec, frac := math.Modf(n)
+		return time.Unix(int64(sec), int64(math.Round(frac*1e9))), nil
+	case EpochExcel1900:
+		switch {
+		case n < 0:
+			return time.Time{}, fmt.Errorf("unable to cast %v to %s Time: negative serial", n, e)
+		case n < 60:
+			return addDays(oleEpoch.AddDate(0, 0, 1), n)
+		case n < 61:
+			return time.Time{}, errExcelLeapDay
+		}
+		return addDays(oleEpoch, n)
+	case EpochExcel1904:
+		if n < 0 {
+			return time.Time{}, fmt.Errorf("unable to cast %v to %s Time: negative serial", n, e)
+		}
+		return addDays(excel1904Epoch, n)
+	case EpochOLE:
+		// Negative OLE dates count whole days backwards but the fraction is
+		// always a time of day, so -1.25 is 1899-12-29 06:00.
+		days, frac := math.Modf(n)
+		return addDays(oleEpoch, days+math.Abs(frac))
+	case EpochJulian:
+		return addDays(unixEpoch, n-julianUnixEpoch)
+	case EpochTicks:
+		if math.Abs(n) >= 1<<63 {
+			return time.Time{}, fmt.Errorf("unable to cast %v to %s Time: out of range", n, e)
+		}
+		return TicksToTime(int64(n)), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown epoch %s", e)
+	}
+}
+
+// Value converts t to a number counted in the units of e; it is the
+// inverse of Time. Excel and OLE serials are taken from the wall clock of t
+// in its own location. Ticks above 2^53 lose precision as a float64, use
+// TimeToTicks to get them exactly.
+func (e Epoch) Value(t time.Time) (float64, error) {
+	switch e {
+	case EpochUnix:
+		return daysSince(unixEpoch, t) * day.Seconds(), nil
+	case EpochExcel1900:
+		v := daysSince(oleEpoch, wallClock(t))
+		if v < 61 {
+			// Dates before 1900-03-01 sit before the phantom leap day.
+			v--
+		}
+		return v, nil
+	case EpochExcel1904:
+		return daysSince(excel1904Epoch, wallClock(t)), nil
+	case EpochOLE:
+		v := daysSince(oleEpoch, wallClock(t))
+		if v < 0 {
+			days := math.Floor(v)
+			return days - (v - days), nil
+		}
+		return v, nil
+	case EpochJulian:
+		return daysSince(unixEpoch, t) + julianUnixEpoch, nil
+	case EpochTicks:
+		return float64(TimeToTicks(t)), nil
+	default:
+		return 0, fmt.Errorf("unknown epoch %s", e)
+	}
+}
+
+// TicksToTime converts .NET ticks to a UTC time.Time.
+func TicksToTime(ticks int64) time.Time {
+	ticks -= ticksUnixEpoch
+	return time.Unix(ticks/ticksPerSecond, ticks%ticksPerSecond*100).UTC()
+}
+
+// TimeToTicks converts t to .NET ticks.
+func TimeToTicks(t time.Time) int64 {
+	return t.Unix()*ticksPerSecond + int64(t.Nanosecond()/100) + ticksUnixEpoch
+}
+
+// EpochTimeE casts a number, or a string holding one, to a time.Time
+// counted in the units of e.
+func EpochTimeE(i interface{}, e Epoch) (time.Time, error) {
+	return std.EpochTimeE(i, e)
+}
+
+// EpochTimeE casts a number, or a string holding one, to a time.Time
+// counted in the units of e.
+func (c *Caster) EpochTimeE(i interface{}, e Epoch) (time.Time, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return time.Time{}, c.nilE("Time")
+	}
+
+	switch v := i.(type) {
+	case time.Time:
+		return v, nil
+	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8, float64, float32, string:
+	default:
+		return time.Time{}, c.castE(i, e.String()+" Time")
+	}
+
+	if e == EpochTicks {
+		// Ticks exceed float64 precision, keep integers exact. Floats, and
+		// unsigned integers beyond int64, take the range checked path.
+		switch v := i.(type) {
+		case float64, float32:
+		case uint, uint64:
+			if n, _ := c.Uint64E(v); n <= math.MaxInt64 {
+				return TicksToTime(int64(n)), nil
+			}
+		default:
+			if n, err := c.Int64E(i); err == nil {
+				return TicksToTime(n), nil
+			}
+		}
+	}
+	n, err := c.Float64E(i)
+	if err != nil {
+		return time.Time{}, c.castE(i, e.String()+" Time")
+	}
+	t, err := e.Time(n)
+	if err != nil {
+		return time.Time{}, c.castE(i, e.String()+" Time")
+	}
+	return t, nil
+}
+
+// EpochValueE casts an interface to a time.Time and converts it to a number
+// counted in the units of e.
+func EpochValueE(i interface{}, e Epoch) (float64, error) {
+	return std.EpochValueE(i, e)
+}
+
+// EpochValueE casts an interface to a time.Time and converts it to a number
+// counted in the units of e.
+func (c *Caster) EpochValueE(i interface{}, e Epoch) (float64, error) {
+	if isNil(i) {
+		return 0, c.nilE("float64")
+	}
+
+	t, err := c.TimeE(i)
+	if err != nil {
+		return 0, err
+	}
+	return e.Value(t)
+}
+
+// addDays adds a fractional number of days to t, rounding the time of day
+// to the microsecond to hide float noise.
+func addDays(t time.Time, n float64) (time.Time, error) {
+	if math.Abs(n) > maxEpochDays {
+		return time.Time{}, fmt.Errorf("unable to add %v days to %s: out of range", n, t)
+	}
+	days, frac := math.Modf(n)
+	us := math.Round(frac * float64(day/time.Microsecond))
+	return t.AddDate(0, 0, int(days)).Add(time.Duration(us) * time.Microsecond), nil
+}
+
+// daysSince returns the fractional number of days from base to t.
+func daysSince(base, t time.Time) float64 {
+	sec := t.Unix() - base.Unix()
+	nsec := t.Nanosecond() - base.Nanosecond()
+	return (float64(sec) + float64(nsec)/1e9) / day.Seconds()
+}
+
+// wallClock returns the wall clock of t as a UTC time.
+func wallClock(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	h, mi, s := t.Clock()
+	return time.Date(y, mo, d, h, mi, s, t.Nanosecond(), time.UTC)
+}
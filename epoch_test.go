@@ -0,0 +1,125 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEpochTimeE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		epoch  Epoch
+		expect time.Time
+		iserr  bool
+	}{
+		{45291.5, EpochExcel1900, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), false},
+		{"45291.5", EpochExcel1900, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), false},
+		{1, EpochExcel1900, time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{59, EpochExcel1900, time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{61, EpochExcel1900, time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{0.25, EpochExcel1904, time.Date(1904, 1, 1, 6, 0, 0, 0, time.UTC), false},
+		{43830, EpochExcel1904, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{0, EpochOLE, time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC), false},
+		{-1.25, EpochOLE, time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC), false},
+		{45291.75, EpochOLE, time.Date(2023, 12, 31, 18, 0, 0, 0, time.UTC), false},
+		{2451545.0, EpochJulian, time.Date(2000, 1, 1, 12, 0, 0, 0, time.UTC), false},
+		{int64(638396640000000000), EpochTicks, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), false},
+		{int64(621355968000000001), EpochTicks, time.Date(1970, 1, 1, 0, 0, 0, 100, time.UTC), false},
+		{1.5, EpochUnix, time.Date(1970, 1, 1, 0, 0, 1, 5e8, time.UTC), false},
+		// errors
+		{60, EpochExcel1900, time.Time{}, true},
+		{-1, EpochExcel1900, time.Time{}, true},
+		{-1, EpochExcel1904, time.Time{}, true},
+		{1e300, EpochJulian, time.Time{}, true},
+		{"test", EpochOLE, time.Time{}, true},
+		{true, EpochOLE, time.Time{}, true},
+		{1, Epoch(42), time.Time{}, true},
+		{float64(1 << 63), EpochTicks, time.Time{}, true},
+		{uint64(1 << 63), EpochTicks, time.Time{}, true},
+		{"9223372036854775808", EpochTicks, time.Time{}, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := EpochTimeE(test.input, test.epoch)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v.UTC(), errmsg)
+
+		// Non-E test
+		v = EpochTime(test.input, test.epoch)
+		assert.Equal(t, test.expect, v.UTC(), errmsg)
+	}
+}
+
+func TestEpochValueE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		epoch  Epoch
+		expect float64
+		iserr  bool
+	}{
+		{time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), EpochExcel1900, 45291.5, false},
+		{time.Date(2023, 12, 31, 12, 0, 0, 0, time.FixedZone("", 3*3600)), EpochExcel1900, 45291.5, false},
+		{time.Date(1900, 2, 28, 0, 0, 0, 0, time.UTC), EpochExcel1900, 59, false},
+		{time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC), EpochExcel1900, 61, false},
+		{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), EpochExcel1904, 43830, false},
+		{time.Date(1899, 12, 29, 6, 0, 0, 0, time.UTC), EpochOLE, -1.25, false},
+		{"2000-01-01T12:00:00Z", EpochJulian, 2451545.0, false},
+		{time.Date(1970, 1, 1, 0, 0, 1, 5e8, time.UTC), EpochUnix, 1.5, false},
+		// errors
+		{"test", EpochOLE, 0, true},
+		{time.Time{}, Epoch(42), 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := EpochValueE(test.input, test.epoch)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.InDelta(t, test.expect, v, 1e-9, errmsg)
+
+		// Non-E test
+		v = EpochValue(test.input, test.epoch)
+		assert.InDelta(t, test.expect, v, 1e-9, errmsg)
+	}
+}
+
+func TestTicks(t *testing.T) {
+	tm := time.Date(2024, 1, 1, 10, 30, 0, 1234500, time.UTC)
+	assert.Equal(t, tm, TicksToTime(TimeToTicks(tm)))
+	assert.Equal(t, int64(0), TimeToTicks(time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestCasterTimeEpoch(t *testing.T) {
+	c := &Caster{TimeEpoch: EpochExcel1900}
+
+	v, err := c.TimeE(45291.5)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2023, 12, 31, 12, 0, 0, 0, time.UTC), v)
+
+	v, err = c.TimeE("2006-01-02")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), v)
+
+	_, err = TimeE(45291.5)
+	assert.Error(t, err)
+}
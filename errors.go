@@ -0,0 +1,161 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// maxListedErrors caps how many element failures ElementErrors spells out.
+const maxListedErrors = 10
+
+// LimitError reports a string input beyond one of the limits of a Caster.
+type LimitError struct {
+	Limit string // name of the Caster field: MaxInputLen, MaxDepth or MaxElements
+	Max   int    // value of the limit
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("input exceeds the %s limit of %d", e.Limit, e.Max)
+}
+
+// ElementError reports a slice element or map entry that failed to cast.
+type ElementError struct {
+	Index int         // index of the slice element, or -1 for a map entry
+	Key   interface{} // key of the map entry
+	To    string      // type the whole value was cast to
+	Err   error       // why the element failed
+
+	key string // Key as the Caster renders values, when set
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("unable to cast %s to %s: %s", e.element(), e.To, e.Err)
+}
+
+// Unwrap returns the error of the element.
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ElementError) element() string {
+	if e.Index < 0 && e.key != "" {
+		return "key " + e.key
+	}
+	if e.Index < 0 {
+		return fmt.Sprintf("key %#v", e.Key)
+	}
+	return fmt.Sprintf("element %d", e.Index)
+}
+
+// ElementErrors gathers every element failure of one cast, for a Caster
+// with CollectErrors set. Map entries are sorted by key.
+type ElementErrors []*ElementError
+
+func (e ElementErrors) Error() string {
+	if len(e) == 0 {
+		return "no errors"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "unable to cast %d elements to %s", len(e), e[0].To)
+	for j, err := range e {
+		if j == maxListedErrors {
+			fmt.Fprintf(&b, "; and %d more", len(e)-j)
+			break
+		}
+		fmt.Fprintf(&b, "; %s: %s", err.element(), err.Err)
+	}
+	return b.String()
+}
+
+// Unwrap returns the errors of the elements.
+func (e ElementErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for j, err := range e {
+		errs[j] = err
+	}
+	return errs
+}
+
+// Is reports whether any element error matches target, for errors.Is on
+// Go releases that do not follow Unwrap() []error.
+func (e ElementErrors) Is(target error) bool {
+	for _, err := range e {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first element error that matches target, for errors.As on
+// Go releases that do not follow Unwrap() []error.
+func (e ElementErrors) As(target interface{}) bool {
+	for _, err := range e {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// elementErrors collects the element failures of one cast to type to.
+type elementErrors struct {
+	redact  func(key string) bool
+	value   func(i interface{}) string
+	lenient bool
+	collect bool
+	to      string
+	errs    ElementErrors
+}
+
+func (c *Caster) elementErrors(to string) *elementErrors {
+	return &elementErrors{redact: c.Redact, value: c.value, lenient: c.Lenient, collect: c.CollectErrors, to: to}
+}
+
+// index records that element j failed, and reports whether to stop.
+// A lenient Caster records nothing.
+func (e *elementErrors) index(j int, err error) bool {
+	if e.lenient {
+		return false
+	}
+	e.errs = append(e.errs, &ElementError{Index: j, To: e.to, Err: err})
+	return !e.collect
+}
+
+// key records that the entry under k failed, and reports whether to stop.
+func (e *elementErrors) key(k interface{}, err error) bool {
+	if e.lenient {
+		return false
+	}
+	if e.redact != nil && e.redact(fmt.Sprint(k)) {
+		err = redactedError{err}
+	}
+	e.errs = append(e.errs, &ElementError{Index: -1, Key: k, To: e.to, Err: err, key: e.value(k)})
+	return !e.collect
+}
+
+// err returns the first failure, all of them as ElementErrors when
+// collecting, or nil.
+func (e *elementErrors) err() error {
+	switch {
+	case len(e.errs) == 0:
+		return nil
+	case !e.collect:
+		return e.errs[0]
+	}
+	sort.SliceStable(e.errs, func(x, y int) bool {
+		a, b := e.errs[x], e.errs[y]
+		if a.Index != b.Index {
+			return a.Index < b.Index
+		}
+		return fmt.Sprint(a.Key) < fmt.Sprint(b.Key)
+	})
+	return e.errs
+}
@@ -0,0 +1,131 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElementError(t *testing.T) {
+	_, err := IntSliceE([]interface{}{1, 2, "x", "y"})
+	var ee *ElementError
+	if assert.True(t, errors.As(err, &ee)) {
+		assert.Equal(t, 2, ee.Index)
+		assert.Equal(t, "[]int", ee.To)
+	}
+	assert.Contains(t, err.Error(), "unable to cast element 2 to []int: ")
+
+	_, err = MapE[string, int](map[string]interface{}{"a": 1, "b": "x"})
+	if assert.True(t, errors.As(err, &ee)) {
+		assert.Equal(t, -1, ee.Index)
+		assert.Equal(t, "b", ee.Key)
+	}
+	assert.Contains(t, err.Error(), `unable to cast key "b" to map[string]int: `)
+
+	_, err = StringMapIntE(map[string]string{"a": "1", "b": "x"})
+	assert.True(t, errors.As(err, &ee))
+
+	_, err = IntSliceE([]interface{}{nil})
+	assert.NoError(t, err)
+	_, err = (&Caster{Nil: NilError}).IntSliceE([]interface{}{1, nil})
+	assert.True(t, errors.Is(err, ErrNil))
+}
+
+func TestCollectErrors(t *testing.T) {
+	c := &Caster{CollectErrors: true}
+
+	v, err := c.IntSliceE([]interface{}{"x", 1, "y"})
+	assert.Equal(t, []int{}, v)
+	var errs ElementErrors
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 2) {
+		assert.Equal(t, 0, errs[0].Index)
+		assert.Equal(t, 2, errs[1].Index)
+	}
+	assert.Len(t, errs.Unwrap(), 2)
+
+	// Is and As walk the elements without relying on Unwrap() []error.
+	_, err = (&Caster{CollectErrors: true, NonFinite: NonFiniteReject}).Float64SliceE([]interface{}{1, math.NaN()})
+	if assert.True(t, errors.As(err, &errs)) {
+		assert.True(t, errs.Is(ErrNaN))
+		assert.False(t, errs.Is(ErrInf))
+		var ee *ElementError
+		if assert.True(t, errs.As(&ee)) {
+			assert.Equal(t, 1, ee.Index)
+		}
+	}
+
+	_, err = MapWithE[string, int](c, map[string]interface{}{"c": "z", "a": "x", "b": 2})
+	if assert.True(t, errors.As(err, &errs)) && assert.Len(t, errs, 2) {
+		assert.Equal(t, "a", errs[0].Key)
+		assert.Equal(t, "c", errs[1].Key)
+	}
+	assert.Contains(t, err.Error(), `unable to cast 2 elements to map[string]int; key "a": `)
+
+	in := make([]interface{}, 12)
+	for j := range in {
+		in[j] = "x"
+	}
+	_, err = c.IntSliceE(in)
+	assert.Contains(t, err.Error(), "; and 2 more")
+
+	_, err = c.IntSliceE([]interface{}{1, 2})
+	assert.NoError(t, err)
+}
+
+func TestStringMapElementErrors(t *testing.T) {
+	tests := []struct {
+		cast  func(c *Caster, i interface{}) (interface{}, error)
+		input interface{}
+		zero  interface{}
+	}{
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapIntE(i) },
+			map[string]interface{}{"a": "oops"}, map[string]int{"a": 0}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapIntE(i) },
+			map[interface{}]interface{}{"a": "oops"}, map[string]int{"a": 0}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapInt64E(i) },
+			map[string]interface{}{"a": "oops"}, map[string]int64{"a": 0}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapBoolE(i) },
+			map[string]interface{}{"a": "oops"}, map[string]bool{"a": false}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapBoolE(i) },
+			map[string]string{"a": "oops"}, map[string]bool{"a": false}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapStringE(i) },
+			map[string]interface{}{"a": []int{1}}, map[string]string{"a": ""}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringMapStringSliceE(i) },
+			map[string]interface{}{"a": []interface{}{testing.T{}}}, map[string][]string{"a": {""}}},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.StringSliceE(i) },
+			[]interface{}{"a", []int{1}}, []string{"a", ""}},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := test.cast(&Caster{}, test.input)
+		var ee *ElementError
+		assert.True(t, errors.As(err, &ee), errmsg)
+
+		v, err := test.cast(&Caster{Lenient: true}, test.input)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.zero, v, errmsg)
+	}
+
+	_, err := StringMapE(map[interface{}]interface{}{struct{ k string }{"foo"}: "bar"})
+	assert.Error(t, err)
+	m, err := StringMapE(map[interface{}]interface{}{"a": nil})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": nil}, m)
+	s, err := StringMapStringE(map[int]string{1: "a"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": "a"}, s)
+
+	l, err := (&Caster{Lenient: true}).IntSliceE([]interface{}{1, "x", 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0, 3}, l)
+}
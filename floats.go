@@ -0,0 +1,140 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// NonFinite selects what casters do with NaN and infinities.
+type NonFinite int
+
+const (
+	// NonFiniteAccept passes NaN and infinities on as Go conversions do,
+	// which leaves integers converted from them undefined.
+	NonFiniteAccept NonFinite = iota
+	// NonFiniteReject fails with an error wrapping ErrNaN or ErrInf, or
+	// strconv.ErrRange for finite floats too large for a float32 or an
+	// integer type.
+	NonFiniteReject
+	// NonFiniteSentinel puts Caster.Sentinel in place of the values
+	// NonFiniteReject fails on.
+	NonFiniteSentinel
+)
+
+// ErrNaN and ErrInf are wrapped by the errors of a Caster using
+// NonFiniteReject when it meets NaN or an infinity.
+var (
+	ErrNaN = errors.New("value is NaN")
+	ErrInf = errors.New("value is infinite")
+)
+
+// ErrInexact is wrapped by the errors of a Caster using Exact when a
+// number cannot be held exactly by the type it is cast to.
+var ErrInexact = errors.New("value cannot be represented exactly")
+
+// finite applies c.NonFinite to the float f met on the way from i to the
+// type named to.
+func (c *Caster) finite(i interface{}, f float64, to string) (float64, error) {
+	switch {
+	case c.NonFinite == NonFiniteAccept || !math.IsNaN(f) && !math.IsInf(f, 0):
+		return f, nil
+	case c.NonFinite == NonFiniteSentinel:
+		return c.Sentinel, nil
+	case math.IsNaN(f):
+		return 0, c.castEf(i, to, "%w", ErrNaN)
+	}
+	return 0, c.castEf(i, to, "%w", ErrInf)
+}
+
+// narrow converts the float64 f met on the way from i to a float32,
+// applying c.NonFinite.
+func (c *Caster) narrow(i interface{}, f float64) (float32, error) {
+	f, err := c.finite(i, f, "float32")
+	if err != nil {
+		return 0, err
+	}
+	v := float32(f)
+	if math.IsInf(float64(v), 0) && !math.IsInf(f, 0) {
+		switch c.NonFinite {
+		case NonFiniteSentinel:
+			return float32(c.Sentinel), nil
+		case NonFiniteReject:
+			return 0, c.castEf(i, "float32", "%w", strconv.ErrRange)
+		}
+	}
+	if c.Exact && float64(v) != f && !math.IsNaN(f) {
+		return 0, c.castEf(i, "float32", "%w", ErrInexact)
+	}
+	return v, nil
+}
+
+// floatInt applies c.NonFinite and c.Exact to the float f met on the way
+// from i to the integer type named to, which has the given bits and
+// signedness.
+func (c *Caster) floatInt(i interface{}, f float64, to string, bits int, signed bool) (float64, error) {
+	f, err := c.finite(i, f, to)
+	if err != nil || c.NonFinite == NonFiniteAccept && !c.Exact {
+		return f, err
+	}
+	lo, hi := 0.0, math.Ldexp(1, bits)
+	if signed {
+		lo, hi = -hi/2, hi/2
+	}
+	if f < lo || f >= hi {
+		switch c.NonFinite {
+		case NonFiniteSentinel:
+			return c.Sentinel, nil
+		case NonFiniteReject:
+			return 0, c.castEf(i, to, "%w", strconv.ErrRange)
+		}
+		return 0, c.castEf(i, to, "%w", ErrInexact)
+	}
+	if c.Exact && f != math.Trunc(f) {
+		return 0, c.castEf(i, to, "%w", ErrInexact)
+	}
+	return f, nil
+}
+
+// floatUint is floatInt for unsigned types, failing for negative numbers
+// once c.NonFinite has had its say on NaN and infinities.
+func (c *Caster) floatUint(i interface{}, f float64, to string, bits int) (float64, error) {
+	f, err := c.finite(i, f, to)
+	if err != nil {
+		return 0, err
+	}
+	if f < 0 {
+		return 0, errNegativeNotAllowed
+	}
+	return c.floatInt(i, f, to, bits, false)
+}
+
+// intFloat converts the integer n met on the way from i to the float type
+// named to, failing under c.Exact when the float does not hold n exactly.
+func (c *Caster) intFloat(i interface{}, n int64, to string) (float64, error) {
+	f := float64(n)
+	if to == "float32" {
+		f = float64(float32(n))
+	}
+	if c.Exact && (f >= 1<<63 || int64(f) != n) {
+		return 0, c.castEf(i, to, "%w", ErrInexact)
+	}
+	return f, nil
+}
+
+// uintFloat is intFloat for unsigned integers.
+func (c *Caster) uintFloat(i interface{}, n uint64, to string) (float64, error) {
+	f := float64(n)
+	if to == "float32" {
+		f = float64(float32(n))
+	}
+	if c.Exact && (f >= 1<<64 || uint64(f) != n) {
+		return 0, c.castEf(i, to, "%w", ErrInexact)
+	}
+	return f, nil
+}
@@ -0,0 +1,140 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonFinite(t *testing.T) {
+	reject := &Caster{NonFinite: NonFiniteReject}
+	sentinel := &Caster{NonFinite: NonFiniteSentinel, Sentinel: 7}
+
+	tests := []struct {
+		cast   func(c *Caster, i interface{}) (interface{}, error)
+		input  interface{}
+		kind   error
+		expect interface{} // under NonFiniteSentinel
+	}{
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, "NaN", ErrNaN, 7.0},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, "-inf", ErrInf, 7.0},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, math.NaN(), ErrNaN, 7.0},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, float32(math.Inf(1)), ErrInf, 7.0},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, math.Inf(1), ErrInf, float32(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, 1e300, strconv.ErrRange, float32(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, "nan", ErrNaN, float32(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.IntE(i) }, math.NaN(), ErrNaN, 7},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int64E(i) }, math.Inf(-1), ErrInf, int64(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int64E(i) }, 1e19, strconv.ErrRange, int64(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int8E(i) }, 128.0, strconv.ErrRange, int8(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int32E(i) }, float32(3e9), strconv.ErrRange, int32(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint8E(i) }, 256.0, strconv.ErrRange, uint8(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint64E(i) }, math.NaN(), ErrNaN, uint64(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint64E(i) }, math.Inf(-1), ErrInf, uint64(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.UintE(i) }, float32(math.Inf(-1)), ErrInf, uint(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint8E(i) }, math.Inf(-1), ErrInf, uint8(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, math.NaN(), ErrNaN, time.Duration(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, float32(math.Inf(1)), ErrInf, time.Duration(7)},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, 1e19, strconv.ErrRange, time.Duration(7)},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := test.cast(&Caster{}, test.input)
+		assert.False(t, errors.Is(err, test.kind), errmsg)
+
+		_, err = test.cast(reject, test.input)
+		assert.True(t, errors.Is(err, test.kind), errmsg)
+
+		v, err := test.cast(sentinel, test.input)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	v, err := sentinel.Int8E(math.NaN())
+	assert.NoError(t, err)
+	assert.Equal(t, int8(7), v)
+	f, err := sentinel.Float32E(1e300)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(7), f)
+
+	_, err = sentinel.Uint64E(-1.0)
+	assert.Error(t, err)
+
+	i8, err := reject.Int8E(-128.0)
+	assert.NoError(t, err)
+	assert.Equal(t, int8(-128), i8)
+	f, err = reject.Float32E(math.MaxFloat32)
+	assert.NoError(t, err)
+	assert.Equal(t, float32(math.MaxFloat32), f)
+	f64, err := reject.Float64E("1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f64)
+
+	f64, err = Float64E("NaN")
+	assert.NoError(t, err)
+	assert.True(t, math.IsNaN(f64))
+}
+
+func TestExact(t *testing.T) {
+	exact := &Caster{Exact: true}
+
+	tests := []struct {
+		cast  func(c *Caster, i interface{}) (interface{}, error)
+		input interface{}
+		fail  bool
+	}{
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, 0.1, true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, 0.5, false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, "0.1", true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, "0.25", false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, 1e300, true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, math.NaN(), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, int32(1<<24 + 1), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, int32(1 << 24), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float32E(i) }, uint32(math.MaxUint32), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, int64(1<<60 + 1), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, int64(1 << 60), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, int64(math.MaxInt64), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, int64(math.MinInt64), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, uint64(math.MaxUint64), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Float64E(i) }, uint(1 << 53), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.IntE(i) }, 1.5, true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.IntE(i) }, -2.0, false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int8E(i) }, 300.0, true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Int64E(i) }, math.Inf(1), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint16E(i) }, float32(0.5), true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.Uint64E(i) }, 1e19, false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, 1.5, true},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, float32(-2), false},
+		{func(c *Caster, i interface{}) (interface{}, error) { return c.DurationE(i) }, 1e19, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := test.cast(&Caster{}, test.input)
+		assert.False(t, errors.Is(err, ErrInexact), errmsg)
+
+		_, err = test.cast(exact, test.input)
+		if test.fail {
+			assert.True(t, errors.Is(err, ErrInexact), errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+	}
+
+	_, err := (&Caster{Exact: true, NonFinite: NonFiniteReject}).Int8E(300.0)
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+}
@@ -0,0 +1,108 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"encoding/json"
+)
+
+// DefaultMaxInputLen caps the length of strings parsed into slices and maps
+// when Caster.MaxInputLen is zero.
+const DefaultMaxInputLen = 16 << 20
+
+// DefaultMaxElements caps how many elements and entries a string parsed
+// into a slice or map may hold when Caster.MaxElements is zero.
+const DefaultMaxElements = 1 << 20
+
+func (c *Caster) maxInputLen() int {
+	if c.MaxInputLen == 0 {
+		return DefaultMaxInputLen
+	}
+	return c.MaxInputLen
+}
+
+func (c *Caster) maxElements() int {
+	if c.MaxElements == 0 {
+		return DefaultMaxElements
+	}
+	return c.MaxElements
+}
+
+// checkLen fails when s is longer than c.MaxInputLen.
+func (c *Caster) checkLen(s string) error {
+	if max := c.maxInputLen(); max > 0 && len(s) > max {
+		return &LimitError{Limit: "MaxInputLen", Max: max}
+	}
+	return nil
+}
+
+// checkElements fails when n elements are more than c.MaxElements.
+func (c *Caster) checkElements(n int) error {
+	if max := c.maxElements(); max > 0 && n > max {
+		return &LimitError{Limit: "MaxElements", Max: max}
+	}
+	return nil
+}
+
+// checkJSON fails when the JSON text s breaks the length, depth or element
+// limits of c. It only looks at the structure of s, leaving syntax errors
+// to the decoder.
+func (c *Caster) checkJSON(s string) error {
+	if err := c.checkLen(s); err != nil {
+		return err
+	}
+	maxDepth := c.maxDepth()
+	depth, elems := 0, 0
+	inString, escaped, opened := false, false, false
+	for j := 0; j < len(s); j++ {
+		b := s[j]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+		switch b {
+		case ' ', '\t', '\n', '\r':
+			continue
+		}
+		if opened && b != ']' && b != '}' {
+			elems++
+		}
+		opened = false
+		switch b {
+		case '"':
+			inString = true
+		case '[', '{':
+			if depth++; depth > maxDepth {
+				return &LimitError{Limit: "MaxDepth", Max: maxDepth}
+			}
+			opened = true
+		case ']', '}':
+			depth--
+		case ',':
+			elems++
+		}
+		if err := c.checkElements(elems); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonStringToObject attempts to unmarshall a string as JSON into
+// the object passed as pointer, within the limits of c.
+func (c *Caster) jsonStringToObject(s string, v interface{}) error {
+	if err := c.checkJSON(s); err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(s), v)
+}
@@ -0,0 +1,63 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInputLimits(t *testing.T) {
+	tests := []struct {
+		c     *Caster
+		input string
+		limit string // "" when within limits
+	}{
+		{&Caster{MaxInputLen: 10}, `{"a": "bcdefgh"}`, "MaxInputLen"},
+		{&Caster{MaxInputLen: 16}, `{"a": "bcdefgh"}`, ""},
+		{&Caster{MaxInputLen: -1}, `{"a": "` + strings.Repeat("x", DefaultMaxInputLen) + `"}`, ""},
+		{&Caster{MaxDepth: 2}, `{"a": {"b": {"c": 1}}}`, "MaxDepth"},
+		{&Caster{MaxDepth: 3}, `{"a": {"b": {"c": 1}}}`, ""},
+		{&Caster{MaxDepth: 1}, `{"a": "{[{["}`, ""},
+		{&Caster{MaxDepth: -1}, `{"a": ` + strings.Repeat("[", DefaultMaxDepth) + strings.Repeat("]", DefaultMaxDepth) + `}`, ""},
+		{&Caster{MaxElements: 3}, `{"a": [1, 2], "b": {}}`, "MaxElements"},
+		{&Caster{MaxElements: 4}, `{"a": [1, 2], "b": {}}`, ""},
+		{&Caster{MaxElements: 1}, `{"a": "x,y,\"z\""}`, ""},
+		{&Caster{MaxElements: 2}, `a=1,b=2,c=3`, "MaxElements"},
+		{&Caster{MaxInputLen: 5}, `a=1,b=2`, "MaxInputLen"},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := test.c.StringMapE(test.input)
+		var le *LimitError
+		if test.limit == "" {
+			assert.False(t, errors.As(err, &le), errmsg)
+			continue
+		}
+		if assert.True(t, errors.As(err, &le), errmsg) {
+			assert.Equal(t, test.limit, le.Limit, errmsg)
+		}
+	}
+
+	c := &Caster{MaxElements: 2, MaxDepth: 1}
+	_, err := c.IntSliceE("1,2,3")
+	var le *LimitError
+	assert.True(t, errors.As(err, &le))
+	_, err = c.StringSliceE("[1, 2, 3]")
+	assert.True(t, errors.As(err, &le))
+	_, err = c.StringSliceE("[[1]]")
+	assert.True(t, errors.As(err, &le))
+	_, err = MapWithE[string, int](c, `{"a": 1, "b": 2, "c": 3}`)
+	assert.EqualError(t, err, `unable to cast "{\"a\": 1, \"b\": 2, \"c\": 3}" of type string to map[string]int: input exceeds the MaxElements limit of 2`)
+	_, err = c.StringMapIntE(`{"a": 1, "b": 2, "c": 3}`)
+	assert.True(t, errors.As(err, &le))
+}
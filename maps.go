@@ -0,0 +1,308 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	durationType  = reflect.TypeOf(time.Duration(0))
+	dateType      = reflect.TypeOf(Date{})
+	timeOfDayType = reflect.TypeOf(TimeOfDay{})
+)
+
+// MapE casts an interface to a map[K]V type. The input may be any map, a
+// struct, whose exported fields are keyed by their JSON names, a JSON
+// object string or key/value pairs as the StringMap casters read them.
+// Keys and values are cast with the caster of their type, and maps and
+// slices among them recursively, so MapE[int, []float64] or
+// MapE[string, map[string]time.Duration] work alike.
+func MapE[K comparable, V any](i interface{}) (map[K]V, error) {
+	return MapWithE[K, V](std, i)
+}
+
+// MapWithE is MapE using the options of c.
+func MapWithE[K comparable, V any](c *Caster, i interface{}) (map[K]V, error) {
+	v, err := c.mapE(i, reflect.TypeOf(map[K]V(nil)))
+	m, _ := v.(map[K]V)
+	return m, err
+}
+
+// Map casts an interface to a map[K]V type.
+func Map[K comparable, V any](i interface{}) map[K]V {
+	v, _ := MapE[K, V](i)
+	return v
+}
+
+// mapE casts i to a map of type typ.
+func (c *Caster) mapE(i interface{}, typ reflect.Type) (interface{}, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return reflect.Zero(typ).Interface(), c.nilE(typ.String())
+	}
+	if reflect.TypeOf(i) == typ {
+		return i, nil
+	}
+
+	if pm, ok, err := c.pairsE(i, false); ok {
+		if err != nil {
+			return reflect.Zero(typ).Interface(), c.castEf(i, typ.String(), "%w", err)
+		}
+		i = pm
+	}
+
+	src := reflect.ValueOf(i)
+	switch src.Kind() {
+	case reflect.Map:
+	case reflect.Struct:
+		src = reflect.ValueOf(structToMap(src))
+	case reflect.String:
+		m, err := c.decodeJSON(src.String())
+		if err != nil {
+			return reflect.Zero(typ).Interface(), c.castEf(i, typ.String(), "%w", err)
+		}
+		src = reflect.ValueOf(m)
+	default:
+		return reflect.Zero(typ).Interface(), c.castE(i, typ.String())
+	}
+
+	m := reflect.MakeMapWithSize(typ, src.Len())
+	errs := c.elementErrors(typ.String())
+	iter := src.MapRange()
+	for iter.Next() {
+		k, err := c.castValue(iter.Key().Interface(), typ.Key())
+		if err == nil {
+			var v reflect.Value
+			if v, err = c.castValue(iter.Value().Interface(), typ.Elem()); err == nil {
+				m.SetMapIndex(k, v)
+				continue
+			}
+			if c.Lenient {
+				m.SetMapIndex(k, reflect.Zero(typ.Elem()))
+			}
+		}
+		if errs.key(iter.Key().Interface(), err) {
+			break
+		}
+	}
+	if err := errs.err(); err != nil {
+		return reflect.Zero(typ).Interface(), err
+	}
+	return m.Interface(), nil
+}
+
+// stringMapE fills m, a map with string keys, from the entries of the map
+// i, casting every value with cast.
+func (c *Caster) stringMapE(i interface{}, m interface{}, cast func(interface{}) (interface{}, error)) error {
+	dst := reflect.ValueOf(m)
+	errs := c.elementErrors(dst.Type().String())
+	iter := reflect.ValueOf(i).MapRange()
+	for iter.Next() {
+		k := iter.Key().Interface()
+		key, err := c.StringE(k)
+		var val interface{}
+		if err == nil {
+			val, err = cast(iter.Value().Interface())
+		}
+		if err == nil || c.Lenient {
+			v := reflect.Zero(dst.Type().Elem())
+			if val != nil {
+				v = reflect.ValueOf(val)
+			}
+			dst.SetMapIndex(reflect.ValueOf(key), v)
+		}
+		if err != nil && errs.key(k, err) {
+			break
+		}
+	}
+	return errs.err()
+}
+
+// castValue casts i to type t with the caster of its kind.
+func (c *Caster) castValue(i interface{}, t reflect.Type) (reflect.Value, error) {
+	if n, ok := i.(jsonNumber); ok {
+		if t.Kind() != reflect.Interface {
+			i = string(n)
+		} else {
+			i = n.float()
+		}
+	}
+	if i != nil && reflect.TypeOf(i) == t {
+		return reflect.ValueOf(plainNumbers(i)), nil
+	}
+
+	var v interface{}
+	var err error
+	switch t {
+	case timeType:
+		v, err = c.TimeE(i)
+	case durationType:
+		v, err = c.DurationE(i)
+	case dateType:
+		v, err = c.DateE(i)
+	case timeOfDayType:
+		v, err = c.TimeOfDayE(i)
+	default:
+		switch t.Kind() {
+		case reflect.Interface:
+			if i == nil {
+				return reflect.Zero(t), nil
+			}
+			if !reflect.TypeOf(i).Implements(t) {
+				return reflect.Value{}, fmt.Errorf("%T does not implement %s", i, t)
+			}
+			return reflect.ValueOf(plainNumbers(i)), nil
+		case reflect.Bool:
+			v, err = c.BoolE(i)
+		case reflect.String:
+			v, err = c.StringE(i)
+		case reflect.Int:
+			v, err = c.IntE(i)
+		case reflect.Int64:
+			v, err = c.Int64E(i)
+		case reflect.Int32:
+			v, err = c.Int32E(i)
+		case reflect.Int16:
+			v, err = c.Int16E(i)
+		case reflect.Int8:
+			v, err = c.Int8E(i)
+		case reflect.Uint:
+			v, err = c.UintE(i)
+		case reflect.Uint64:
+			v, err = c.Uint64E(i)
+		case reflect.Uint32:
+			v, err = c.Uint32E(i)
+		case reflect.Uint16:
+			v, err = c.Uint16E(i)
+		case reflect.Uint8:
+			v, err = c.Uint8E(i)
+		case reflect.Float64:
+			v, err = c.Float64E(i)
+		case reflect.Float32:
+			v, err = c.Float32E(i)
+		case reflect.Slice:
+			v, err = c.sliceE(i, t, func(e interface{}) (interface{}, error) {
+				r, err := c.castValue(e, t.Elem())
+				if err != nil || !r.IsValid() {
+					return nil, err
+				}
+				return r.Interface(), nil
+			})
+		case reflect.Map:
+			v, err = c.mapE(i, t)
+		default:
+			return reflect.Value{}, c.castEf(i, t.String(), "unsupported type")
+		}
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return reflect.ValueOf(v).Convert(t), nil
+}
+
+// jsonNumber is a number mapE decoded from a JSON string, kept as its text
+// so that the integer casters read it exactly.
+type jsonNumber string
+
+// float returns n as encoding/json would have decoded it.
+func (n jsonNumber) float() float64 {
+	f, _ := strconv.ParseFloat(string(n), 64)
+	return f
+}
+
+// decodeJSON decodes the JSON object s within the limits of c, keeping its
+// numbers as jsonNumber.
+func (c *Caster) decodeJSON(s string) (map[string]interface{}, error) {
+	if err := c.checkJSON(s); err != nil {
+		return nil, err
+	}
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+	var m map[string]interface{}
+	if err := d.Decode(&m); err != nil {
+		return nil, err
+	}
+	if _, err := d.Token(); err != io.EOF {
+		return nil, errors.New("invalid character after top-level value")
+	}
+	markNumbers(m)
+	return m, nil
+}
+
+// markNumbers turns the json.Number values decoded into v into jsonNumber.
+func markNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		return jsonNumber(v)
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = markNumbers(e)
+		}
+	case []interface{}:
+		for j, e := range v {
+			v[j] = markNumbers(e)
+		}
+	}
+	return v
+}
+
+// plainNumbers turns the jsonNumber values nested in v into float64, for
+// values that leave mapE as they are. Maps and slices holding none are left
+// untouched.
+func plainNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case jsonNumber:
+		return v.float()
+	case map[string]interface{}:
+		for k, e := range v {
+			if n, ok := e.(jsonNumber); ok {
+				v[k] = n.float()
+			} else {
+				plainNumbers(e)
+			}
+		}
+	case []interface{}:
+		for j, e := range v {
+			if n, ok := e.(jsonNumber); ok {
+				v[j] = n.float()
+			} else {
+				plainNumbers(e)
+			}
+		}
+	}
+	return v
+}
+
+// structToMap returns the exported fields of the struct v keyed by their
+// JSON names.
+func structToMap(v reflect.Value) map[string]interface{} {
+	m := make(map[string]interface{}, v.NumField())
+	t := v.Type()
+	for j := 0; j < t.NumField(); j++ {
+		f := t.Field(j)
+		if f.PkgPath != "" {
+			continue
+		}
+		name := f.Name
+		if tag := strings.Split(f.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		m[name] = v.Field(j).Interface()
+	}
+	return m
+}
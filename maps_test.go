@@ -0,0 +1,109 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapE(t *testing.T) {
+	ints, err := MapE[int, string](map[string]interface{}{"1": "a", "2": 3})
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]string{1: "a", 2: "3"}, ints)
+
+	floats, err := MapE[string, float64](`{"a": 1.5, "b": "2"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"a": 1.5, "b": 2}, floats)
+
+	durations, err := MapE[string, time.Duration](map[interface{}]interface{}{"a": "1s", "b": 5})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Duration{"a": time.Second, "b": 5}, durations)
+
+	times, err := MapE[string, time.Time](map[string]string{"a": "2006-01-02"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]time.Time{"a": time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)}, times)
+
+	nested, err := MapE[string, map[string]string](map[interface{}]interface{}{
+		"a": map[interface{}]interface{}{"x": 1},
+		"b": map[string]int{"y": 2},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]string{"a": {"x": "1"}, "b": {"y": "2"}}, nested)
+
+	slices, err := MapE[string, []int](`{"a": [1, 2], "b": "3,4"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int{"a": {1, 2}, "b": {3, 4}}, slices)
+
+	anys, err := MapE[string, interface{}](map[interface{}]interface{}{"a": nil, 1: []interface{}{nil}})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": nil, "1": []interface{}{nil}}, anys)
+
+	type weekday int
+	named, err := MapE[string, weekday](map[string]string{"mon": "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]weekday{"mon": 1}, named)
+
+	same := map[string]int{"a": 1}
+	v, err := MapE[string, int](&same)
+	assert.NoError(t, err)
+	assert.Equal(t, same, v)
+
+	v, err = MapE[string, int](nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+	assert.Equal(t, map[int]bool{1: true}, Map[int, bool](map[string]string{"1": "yes"}))
+
+	// JSON numbers reach the integer casters exactly, and interface values
+	// still hold float64.
+	big, err := MapE[string, int64](`{"a": 9007199254740993}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 9007199254740993}, big)
+	unsigned, err := MapE[string, []uint64](`{"a": [18446744073709551615]}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]uint64{"a": {18446744073709551615}}, unsigned)
+	strs, err := MapE[string, string](`{"a": 9007199254740993}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "9007199254740993"}, strs)
+	values, err := MapE[string, interface{}](`{"a": 1.5, "b": {"c": [2]}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": 1.5, "b": map[string]interface{}{"c": []interface{}{2.0}}}, values)
+	objs, err := MapE[string, map[string]interface{}](`{"a": {"b": 3}}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]map[string]interface{}{"a": {"b": 3.0}}, objs)
+
+	// errors
+	_, err = MapE[string, int](`{"a": 1} x`)
+	assert.Error(t, err)
+	_, err = MapE[int, string](map[string]string{"x": "a"})
+	assert.Error(t, err)
+	_, err = MapE[string, int](map[string]string{"a": "x"})
+	assert.Error(t, err)
+	_, err = MapE[string, int]("{")
+	assert.Error(t, err)
+	_, err = MapE[string, int](42)
+	assert.Error(t, err)
+	_, err = MapE[string, *int](map[string]int{"a": 1})
+	assert.Error(t, err)
+	_, err = MapWithE[string, int](&Caster{Nil: NilError}, nil)
+	assert.True(t, errors.Is(err, ErrNil))
+}
+
+func TestMapEStruct(t *testing.T) {
+	type config struct {
+		Name    string `json:"name"`
+		Retries string
+		Skip    int `json:"-"`
+		hidden  int
+	}
+
+	m, err := MapE[string, string](config{Name: "a", Retries: "3", Skip: 1, hidden: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"name": "a", "Retries": "3"}, m)
+}
@@ -0,0 +1,164 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxDepth caps how deeply nested a value may be when
+// Caster.MaxDepth is zero.
+const DefaultMaxDepth = 10000
+
+// NumberForm selects the type NormalizeE gives the numbers it meets.
+type NumberForm int
+
+const (
+	// NumberAsIs keeps numbers as they are.
+	NumberAsIs NumberForm = iota
+	// NumberInt64 turns integers into int64 and floats into float64.
+	NumberInt64
+	// NumberFloat64 turns every number into float64, as encoding/json
+	// decodes them.
+	NumberFloat64
+)
+
+// NormalizeE casts an interface, such as a tree decoded by yaml.v2, to one
+// that encoding/json accepts: every map, however deep, becomes a
+// map[string]interface{} and every slice or array but []byte a
+// []interface{}, with numbers converted as c.Numbers asks.
+func NormalizeE(i interface{}) (interface{}, error) {
+	return std.NormalizeE(i)
+}
+
+// NormalizeE casts an interface, such as a tree decoded by yaml.v2, to one
+// that encoding/json accepts: every map, however deep, becomes a
+// map[string]interface{} and every slice or array but []byte a
+// []interface{}, with numbers converted as c.Numbers asks. Trees nested
+// deeper than c.MaxDepth or holding themselves are rejected. A nil input
+// follows c.Nil; nil values within the tree stay nil.
+func (c *Caster) NormalizeE(i interface{}) (interface{}, error) {
+	if isNil(i) {
+		return nil, c.nilE("interface {}")
+	}
+	n := normalizer{c: c, max: c.maxDepth(), stack: map[uintptr]bool{}}
+	return n.value(i, "", 0)
+}
+
+func (c *Caster) maxDepth() int {
+	switch {
+	case c.MaxDepth == 0:
+		return DefaultMaxDepth
+	case c.MaxDepth < 0:
+		return math.MaxInt
+	}
+	return c.MaxDepth
+}
+
+type normalizer struct {
+	c     *Caster
+	max   int
+	stack map[uintptr]bool // maps and slices being normalized, to spot cycles
+}
+
+func (n *normalizer) value(i interface{}, path string, depth int) (interface{}, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return nil, nil
+	}
+	if depth > n.max {
+		return nil, fmt.Errorf("unable to normalize %s: nested deeper than %d", pathName(path), n.max)
+	}
+
+	v := reflect.ValueOf(i)
+	switch v.Kind() {
+	case reflect.Map:
+		if err := n.enter(v, path); err != nil {
+			return nil, err
+		}
+		defer delete(n.stack, v.Pointer())
+
+		m := make(map[string]interface{}, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			k, err := n.c.StringE(iter.Key().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("unable to normalize key %s of %s: %s", n.c.value(iter.Key().Interface()), pathName(path), err)
+			}
+			if _, ok := m[k]; ok {
+				return nil, fmt.Errorf("unable to normalize %s: key %q appears twice", pathName(path), k)
+			}
+			if m[k], err = n.value(iter.Value().Interface(), path+"."+k, depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return m, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return i, nil
+		}
+		if v.Kind() == reflect.Slice && v.Len() > 0 {
+			if err := n.enter(v, path); err != nil {
+				return nil, err
+			}
+			defer delete(n.stack, v.Pointer())
+		}
+
+		a := make([]interface{}, v.Len())
+		for j := range a {
+			var err error
+			if a[j], err = n.value(v.Index(j).Interface(), path+"["+strconv.Itoa(j)+"]", depth+1); err != nil {
+				return nil, err
+			}
+		}
+		return a, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		switch n.c.Numbers {
+		case NumberInt64:
+			return v.Int(), nil
+		case NumberFloat64:
+			return float64(v.Int()), nil
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		switch n.c.Numbers {
+		case NumberInt64:
+			if v.Uint() > math.MaxInt64 {
+				return nil, fmt.Errorf("unable to normalize %s: %d overflows int64", pathName(path), v.Uint())
+			}
+			return int64(v.Uint()), nil
+		case NumberFloat64:
+			return float64(v.Uint()), nil
+		}
+	case reflect.Float32, reflect.Float64:
+		if n.c.Numbers != NumberAsIs {
+			return v.Float(), nil
+		}
+	}
+	return i, nil
+}
+
+// enter marks the map or slice v as being normalized, failing if it
+// already is, which means it holds itself.
+func (n *normalizer) enter(v reflect.Value, path string) error {
+	p := v.Pointer()
+	if n.stack[p] {
+		return fmt.Errorf("unable to normalize %s: it holds itself", pathName(path))
+	}
+	n.stack[p] = true
+	return nil
+}
+
+func pathName(path string) string {
+	if path == "" {
+		return "value"
+	}
+	return strings.TrimPrefix(path, ".")
+}
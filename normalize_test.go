@@ -0,0 +1,103 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeE(t *testing.T) {
+	yaml := map[interface{}]interface{}{
+		"name": "app",
+		1:      true,
+		"servers": []interface{}{
+			map[interface{}]interface{}{"host": "a", "port": 80},
+			map[interface{}]interface{}{"host": "b", "tags": []string{"x"}},
+		},
+		"raw": []byte("ab"),
+	}
+	expect := map[string]interface{}{
+		"name": "app",
+		"1":    true,
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "port": 80},
+			map[string]interface{}{"host": "b", "tags": []interface{}{"x"}},
+		},
+		"raw": []byte("ab"),
+	}
+
+	v, err := NormalizeE(yaml)
+	assert.NoError(t, err)
+	assert.Equal(t, expect, v)
+	assert.Equal(t, expect, Normalize(&yaml))
+
+	_, err = json.Marshal(v)
+	assert.NoError(t, err)
+
+	v, err = NormalizeE(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestNormalizeNumbers(t *testing.T) {
+	in := []interface{}{int8(1), uint16(2), float32(1.5), "3"}
+
+	v, err := (&Caster{Numbers: NumberInt64}).NormalizeE(in)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(1), int64(2), float64(1.5), "3"}, v)
+
+	v, err = (&Caster{Numbers: NumberFloat64}).NormalizeE(in)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{float64(1), float64(2), float64(1.5), "3"}, v)
+
+	_, err = (&Caster{Numbers: NumberInt64}).NormalizeE([]uint64{1 << 63})
+	assert.Error(t, err)
+}
+
+func TestNormalizeLimits(t *testing.T) {
+	cyclic := map[interface{}]interface{}{}
+	cyclic["self"] = []interface{}{cyclic}
+	_, err := NormalizeE(cyclic)
+	assert.EqualError(t, err, "unable to normalize self[0]: it holds itself")
+
+	loop := []interface{}{nil}
+	loop[0] = loop
+	_, err = NormalizeE(loop)
+	assert.Error(t, err)
+
+	shared := map[string]interface{}{"x": 1}
+	_, err = NormalizeE([]interface{}{shared, shared})
+	assert.NoError(t, err)
+
+	deep := interface{}("leaf")
+	for j := 0; j < 5; j++ {
+		deep = []interface{}{deep}
+	}
+	_, err = (&Caster{MaxDepth: 5}).NormalizeE(deep)
+	assert.NoError(t, err)
+	_, err = (&Caster{MaxDepth: 4}).NormalizeE(deep)
+	assert.Error(t, err)
+	_, err = (&Caster{MaxDepth: -1}).NormalizeE(deep)
+	assert.NoError(t, err)
+
+	c := &Caster{Nil: NilError}
+	_, err = c.NormalizeE(nil)
+	assert.True(t, errors.Is(err, ErrNil))
+	_, err = c.NormalizeE((*map[string]int)(nil))
+	assert.True(t, errors.Is(err, ErrNil))
+	v, err := c.NormalizeE(map[string]interface{}{"a": nil})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": nil}, v)
+
+	_, err = NormalizeE(map[interface{}]interface{}{1: "a", "1": "b"})
+	assert.Error(t, err)
+	_, err = NormalizeE(map[interface{}]interface{}{struct{}{}: "a"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,495 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Locale describes how numbers are written in text: the rune separating
+// the fraction and the runes that may group the digits of the integer
+// part. The zero Locale reads numbers the way strconv does.
+type Locale struct {
+	Decimal  rune   // decimal separator, '.' when zero
+	Grouping string // grouping separators, which must not hold Decimal
+
+	// Strict rejects grouping separators that do not split the integer
+	// part into groups of three digits, such as in "1,23,4".
+	Strict bool
+}
+
+// Common locales.
+var (
+	LocaleEnglish = Locale{Decimal: '.', Grouping: ","}             // 1,234.56
+	LocaleGerman  = Locale{Decimal: ',', Grouping: "."}             // 1.234,56
+	LocaleFrench  = Locale{Decimal: ',', Grouping: " \u00a0\u202f"} // 1 234,56
+	LocaleSwiss   = Locale{Decimal: '.', Grouping: "'’"}            // 1'234.56
+)
+
+// normalize rewrites the number s written in l the way strconv reads it.
+// Numbers with a base prefix such as "0x" are left alone.
+func (l Locale) normalize(s string) (string, error) {
+	if l.Decimal == 0 && l.Grouping == "" {
+		return s, nil
+	}
+	decimal := l.Decimal
+	if decimal == 0 {
+		decimal = '.'
+	}
+
+	t := strings.TrimSpace(s)
+	if hasBasePrefix(t) {
+		return s, nil
+	}
+
+	var b strings.Builder
+	digits, groups := 0, 0 // digits in the current group, groups before it
+	grouped := func() error {
+		if l.Strict && groups > 0 && digits != 3 {
+			return errors.New("digits not grouped by three")
+		}
+		return nil
+	}
+	for j, r := range t {
+		switch {
+		case '0' <= r && r <= '9':
+			b.WriteRune(r)
+			digits++
+			continue
+		case groups >= 0 && strings.ContainsRune(l.Grouping, r):
+			next, _ := utf8.DecodeRuneInString(t[j+utf8.RuneLen(r):])
+			if digits == 0 || next < '0' || next > '9' {
+				return "", fmt.Errorf("misplaced grouping separator %q", r)
+			}
+			if l.Strict && (groups == 0 && digits > 3) {
+				return "", errors.New("digits not grouped by three")
+			}
+			if err := grouped(); err != nil {
+				return "", err
+			}
+			groups++
+			digits = 0
+			continue
+		case groups >= 0 && (r == decimal || r == 'e' || r == 'E'):
+			if err := grouped(); err != nil {
+				return "", err
+			}
+			if r == decimal {
+				r = '.'
+			}
+			groups = -1 // the integer part is over
+		}
+		b.WriteRune(r)
+	}
+	if groups >= 0 {
+		if err := grouped(); err != nil {
+			return "", err
+		}
+	}
+	return b.String(), nil
+}
+
+// asciiDigits maps runes numbers are written with to ASCII.
+var asciiDigits = strings.NewReplacer(
+	"\u2212", "-", // minus sign
+	"\ufe63", "-", // small hyphen-minus
+	"\ufe62", "+", // small plus sign
+	"\u066b", ".", // Arabic decimal separator
+	"\u066c", ",", // Arabic thousands separator
+)
+
+// ascii rewrites s with ASCII digits, signs and spaces in place of the
+// Unicode decimal digits, sign variants, full-width forms and spaces it
+// holds, trimming white space around it, when c.UnicodeDigits is set.
+func (c *Caster) ascii(s string) string {
+	if !c.UnicodeDigits {
+		return s
+	}
+	s = asciiDigits.Replace(strings.TrimSpace(s))
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r < utf8.RuneSelf:
+			return r
+		case '\uff01' <= r && r <= '\uff5e': // full-width ASCII
+			return r - '\uff01' + '!'
+		case unicode.IsDigit(r):
+			// Decimal digits run from 0 to 9 in blocks of ten.
+			n := 0
+			for unicode.IsDigit(r - rune(n) - 1) {
+				n++
+			}
+			return '0' + rune(n%10)
+		case unicode.IsSpace(r):
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// ErrLostFraction is wrapped by the errors of integer casters given a
+// number with a fraction, such as "1.5" or "15e-1".
+var ErrLostFraction = errors.New("fractional part would be lost")
+
+// maxExponent bounds the exponents parseDecimal accepts, as big.Rat would
+// spell out any of them.
+const maxExponent = 1000
+
+// parseInt parses s as strconv.ParseInt does with c.base, reading it in
+// c.Locale after c.ascii. Decimals and exponents, such as "8.0" or "1e6",
+// are accepted when they name a whole number.
+func (c *Caster) parseInt(s string, bits int) (int64, error) {
+	s, err := c.Locale.normalize(c.ascii(s))
+	if err != nil {
+		return 0, err
+	}
+	s, base := c.base(s)
+	v, err := strconv.ParseInt(s, base, bits)
+	if err == nil || !isDecimal(s) {
+		return v, err
+	}
+	n, err := parseDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	if bits == 0 {
+		bits = strconv.IntSize
+	}
+	lim := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+	if n.Cmp(lim) >= 0 || n.Cmp(new(big.Int).Neg(lim)) < 0 {
+		return 0, strconv.ErrRange
+	}
+	return n.Int64(), nil
+}
+
+// parseUint parses s as strconv.ParseUint does with c.base, reading it in
+// c.Locale after c.ascii. Decimals and exponents, such as "8.0" or "1e6",
+// are accepted when they name a whole number.
+func (c *Caster) parseUint(s string, bits int) (uint64, error) {
+	s, err := c.Locale.normalize(c.ascii(s))
+	if err != nil {
+		return 0, err
+	}
+	s, base := c.base(s)
+	v, err := strconv.ParseUint(s, base, bits)
+	if err == nil || !isDecimal(s) {
+		return v, err
+	}
+	n, err := parseDecimal(s)
+	if err != nil {
+		return 0, err
+	}
+	if bits == 0 {
+		bits = strconv.IntSize
+	}
+	if n.Sign() < 0 {
+		return 0, strconv.ErrSyntax
+	}
+	if n.BitLen() > bits {
+		return 0, strconv.ErrRange
+	}
+	return n.Uint64(), nil
+}
+
+// base returns the base strconv should read the integer s in: 0, to
+// follow its prefix, or 10 with c.NoBasePrefix set, when it also drops
+// the underscores strconv would not accept then.
+func (c *Caster) base(s string) (string, int) {
+	if !c.NoBasePrefix {
+		return s, 0
+	}
+	if t, ok := stripUnderscores(s); ok {
+		s = t
+	}
+	return s, 10
+}
+
+// parseFloat parses s as strconv.ParseFloat does, reading it in c.Locale
+// after c.ascii. Underscores may separate digits as in Go literals.
+func (c *Caster) parseFloat(s string, bits int) (float64, error) {
+	s, err := c.Locale.normalize(c.ascii(s))
+	if err != nil {
+		return 0, err
+	}
+	if t, ok := stripUnderscores(s); ok {
+		s = t
+	}
+	return strconv.ParseFloat(s, bits)
+}
+
+// isDecimal reports whether s may be a decimal number with a fraction or
+// an exponent, as opposed to an integer or one with a base prefix.
+func isDecimal(s string) bool {
+	return !hasBasePrefix(s) && strings.ContainsAny(s, ".eE")
+}
+
+// hasBasePrefix reports whether the number s starts with a base prefix
+// such as "0x", after its sign.
+func hasBasePrefix(s string) bool {
+	u := strings.TrimLeft(s, "+-")
+	return len(u) > 1 && u[0] == '0' && strings.ContainsRune("xXbBoO", rune(u[1]))
+}
+
+// parseDecimal parses the decimal number s, which must be whole.
+func parseDecimal(s string) (*big.Int, error) {
+	r, err := parseRat(s)
+	if err != nil {
+		return nil, err
+	}
+	if !r.IsInt() {
+		return nil, ErrLostFraction
+	}
+	return r.Num(), nil
+}
+
+// parseRat parses the decimal number s exactly.
+func parseRat(s string) (*big.Rat, error) {
+	s, ok := stripUnderscores(s)
+	if !ok || strings.ContainsRune(s, '/') {
+		return nil, strconv.ErrSyntax
+	}
+	if e := strings.IndexAny(s, "eE"); e >= 0 {
+		exp, err := strconv.Atoi(s[e+1:])
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return nil, strconv.ErrSyntax
+		}
+		if err != nil || exp > maxExponent || exp < -maxExponent {
+			return nil, strconv.ErrRange
+		}
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, strconv.ErrSyntax
+	}
+	return r, nil
+}
+
+// stripUnderscores drops the underscores of s that sit between two
+// decimal digits, reporting false if s holds any other.
+func stripUnderscores(s string) (string, bool) {
+	if !strings.Contains(s, "_") {
+		return s, true
+	}
+	isDigit := func(j int) bool {
+		return 0 <= j && j < len(s) && '0' <= s[j] && s[j] <= '9'
+	}
+	var b strings.Builder
+	for j := 0; j < len(s); j++ {
+		if s[j] == '_' {
+			if !isDigit(j-1) || !isDigit(j+1) {
+				return s, false
+			}
+			continue
+		}
+		b.WriteByte(s[j])
+	}
+	return b.String(), true
+}
+
+// parseE returns the error for failing to parse the string i as the type
+// named to. Of a strconv error it keeps only the reason, as the rest
+// repeats i.
+func (c *Caster) parseE(i interface{}, to string, err error) error {
+	var ne *strconv.NumError
+	if errors.As(err, &ne) {
+		err = ne.Err
+	}
+	return c.castEf(i, to, "%w", err)
+}
+
+// Float64LocaleE casts an interface to a float64 type, reading strings
+// in l.
+func Float64LocaleE(i interface{}, l Locale) (float64, error) {
+	return std.Float64LocaleE(i, l)
+}
+
+// Float64LocaleE casts an interface to a float64 type, reading strings
+// in l.
+func (c *Caster) Float64LocaleE(i interface{}, l Locale) (float64, error) {
+	cl := *c
+	cl.Locale = l
+	return cl.Float64E(i)
+}
+
+// Int64LocaleE casts an interface to an int64 type, reading strings in l.
+func Int64LocaleE(i interface{}, l Locale) (int64, error) {
+	return std.Int64LocaleE(i, l)
+}
+
+// Int64LocaleE casts an interface to an int64 type, reading strings in l.
+func (c *Caster) Int64LocaleE(i interface{}, l Locale) (int64, error) {
+	cl := *c
+	cl.Locale = l
+	return cl.Int64E(i)
+}
+
+// NumberFormat describes how to write numbers in text.
+type NumberFormat struct {
+	// Locale gives the decimal separator and, in the first rune of its
+	// Grouping, the separator put between groups of three digits.
+	Locale Locale
+
+	// MinFraction pads the fraction with zeros to at least that many
+	// digits, and a positive MaxFraction rounds it, half away from zero, to
+	// at most that many. Otherwise every digit is kept.
+	MinFraction int
+	MaxFraction int
+
+	// Whole rounds numbers, half away from zero, to whole ones, whatever
+	// MaxFraction says.
+	Whole bool
+
+	// Significant rounds numbers to that many significant digits when
+	// positive.
+	Significant int
+}
+
+// FormatNumberE casts an interface to a number and writes it in f.
+func FormatNumberE(i interface{}, f NumberFormat) (string, error) {
+	return std.FormatNumberE(i, f)
+}
+
+// FormatNumberE casts an interface to a number and writes it in f.
+// Floats are rounded from their shortest decimal form, so 2.675 rounds to
+// 2.68 with two fraction digits.
+func (c *Caster) FormatNumberE(i interface{}, f NumberFormat) (string, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return "", c.nilE("number")
+	}
+
+	if s, ok := f.format(i); ok {
+		return s, nil
+	}
+	if s, ok := i.(string); ok {
+		if n, err := c.parseInt(s, 64); err == nil {
+			s, _ := f.format(n)
+			return s, nil
+		}
+	}
+	v, err := c.Float64E(i)
+	if err != nil {
+		return "", c.castE(i, "number")
+	}
+	s, _ := f.format(v)
+	return s, nil
+}
+
+// format writes i in f when it is a number.
+func (f NumberFormat) format(i interface{}) (string, bool) {
+	var s string
+	switch v := i.(type) {
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int:
+		s = strconv.FormatInt(int64(v), 10)
+	case int64:
+		s = strconv.FormatInt(v, 10)
+	case int32:
+		s = strconv.FormatInt(int64(v), 10)
+	case int16:
+		s = strconv.FormatInt(int64(v), 10)
+	case int8:
+		s = strconv.FormatInt(int64(v), 10)
+	case uint:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint64:
+		s = strconv.FormatUint(v, 10)
+	case uint32:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint16:
+		s = strconv.FormatUint(uint64(v), 10)
+	case uint8:
+		s = strconv.FormatUint(uint64(v), 10)
+	default:
+		return "", false
+	}
+
+	if strings.Trim(s, "-0123456789.") != "" {
+		return s, true // NaN or Inf
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	ip, fp := s, ""
+	if dot := strings.IndexByte(s, '.'); dot >= 0 {
+		ip, fp = s[:dot], s[dot+1:]
+	}
+
+	if f.Significant > 0 {
+		first := strings.IndexFunc(ip+fp, func(r rune) bool { return r != '0' })
+		if first >= 0 {
+			ip, fp = roundDigits(ip, fp, first+f.Significant)
+		}
+	}
+	switch {
+	case f.Whole:
+		ip, fp = roundDigits(ip, fp, len(ip))
+	case f.MaxFraction > 0:
+		ip, fp = roundDigits(ip, fp, len(ip)+f.MaxFraction)
+	}
+	fp = strings.TrimRight(fp, "0")
+	for len(fp) < f.MinFraction {
+		fp += "0"
+	}
+
+	var b strings.Builder
+	if neg && strings.Trim(ip+fp, "0") != "" {
+		b.WriteByte('-')
+	}
+	group, _ := utf8.DecodeRuneInString(f.Locale.Grouping)
+	for j, d := range ip {
+		if j > 0 && (len(ip)-j)%3 == 0 && f.Locale.Grouping != "" {
+			b.WriteRune(group)
+		}
+		b.WriteRune(d)
+	}
+	if fp != "" {
+		decimal := f.Locale.Decimal
+		if decimal == 0 {
+			decimal = '.'
+		}
+		b.WriteRune(decimal)
+		b.WriteString(fp)
+	}
+	return b.String(), true
+}
+
+// roundDigits rounds the number with integer digits ip and fraction
+// digits fp, half away from zero, to its first n digits. Integer digits
+// past n become zeros.
+func roundDigits(ip, fp string, n int) (string, string) {
+	d := []byte(ip + fp)
+	if n >= len(d) {
+		return ip, fp
+	}
+	intLen := len(ip)
+	up := d[n] >= '5'
+	d = d[:n]
+	for j := n - 1; up && j >= 0; j-- {
+		if d[j] == '9' {
+			d[j] = '0'
+		} else {
+			d[j]++
+			up = false
+		}
+	}
+	if up {
+		d = append([]byte{'1'}, d...)
+		intLen++
+	}
+	for len(d) < intLen {
+		d = append(d, '0')
+	}
+	return string(d[:intLen]), string(d[intLen:])
+}
@@ -0,0 +1,284 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocaleFloat64E(t *testing.T) {
+	strict := LocaleEnglish
+	strict.Strict = true
+
+	tests := []struct {
+		locale Locale
+		input  string
+		expect float64
+		iserr  bool
+	}{
+		{Locale{}, "1234.56", 1234.56, false},
+		{Locale{}, "1,234.56", 0, true},
+		{LocaleEnglish, "1,234.56", 1234.56, false},
+		{LocaleEnglish, "-1,234,567", -1234567, false},
+		{LocaleEnglish, "1,23,4.5", 1234.5, false},
+		{LocaleEnglish, "1.5e3", 1500, false},
+		{LocaleGerman, "1.234,56", 1234.56, false},
+		{LocaleGerman, "1234,5", 1234.5, false},
+		{LocaleFrench, "1 234,56", 1234.56, false},
+		{LocaleFrench, "1 234 567,8", 1234567.8, false},
+		{LocaleSwiss, "1'234.56", 1234.56, false},
+		{LocaleSwiss, "1’234", 1234, false},
+		{strict, "1,234,567.5", 1234567.5, false},
+		{strict, "12,345", 12345, false},
+		{strict, "1234", 1234, false},
+		// errors
+		{LocaleEnglish, ",123", 0, true},
+		{LocaleEnglish, "1,,234", 0, true},
+		{LocaleEnglish, "1,234,", 0, true},
+		{LocaleEnglish, "1.234,5", 0, true},
+		{LocaleGerman, "1,234.5", 0, true},
+		{strict, "1,23,4.5", 0, true},
+		{strict, "1234,567", 0, true},
+		{strict, "1,2345", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := Float64LocaleE(test.input, test.locale)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestCasterLocale(t *testing.T) {
+	c := &Caster{Locale: LocaleGerman}
+
+	i64, err := c.Int64E("1.234.567")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1234567), i64)
+
+	i64, err = Int64LocaleE("0x1F", LocaleGerman)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(31), i64)
+
+	u, err := c.Uint16E("12.345")
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(12345), u)
+
+	f, err := c.Float32E("2,5")
+	assert.NoError(t, err)
+	assert.Equal(t, float32(2.5), f)
+
+	l, err := (&Caster{Locale: LocaleFrench, Separators: ";"}).Float64SliceE("1 000,5; 2,25")
+	assert.NoError(t, err)
+	assert.Equal(t, []float64{1000.5, 2.25}, l)
+
+	_, err = c.IntE("1,5")
+	assert.Error(t, err)
+	_, err = (&Caster{Locale: Locale{Grouping: ",", Strict: true}}).IntE("1,00")
+	assert.EqualError(t, err, `unable to cast "1,00" of type string to int: digits not grouped by three`)
+}
+
+func TestFormatNumberE(t *testing.T) {
+	english := NumberFormat{Locale: LocaleEnglish}
+	fixed2 := NumberFormat{Locale: LocaleEnglish, MinFraction: 2, MaxFraction: 2}
+	french := NumberFormat{Locale: LocaleFrench, MinFraction: 2, MaxFraction: 2}
+	french.Locale.Grouping = " "
+
+	tests := []struct {
+		format NumberFormat
+		input  interface{}
+		expect string
+		iserr  bool
+	}{
+		{english, 1234567.891, "1,234,567.891", false},
+		{english, -1234, "-1,234", false},
+		{english, 123, "123", false},
+		{english, uint64(18446744073709551615), "18,446,744,073,709,551,615", false},
+		{english, float32(0.1), "0.1", false},
+		{english, int8(-100), "-100", false},
+		{fixed2, 1234567.891, "1,234,567.89", false},
+		{fixed2, 2.675, "2.68", false},
+		{fixed2, 999.996, "1,000.00", false},
+		{fixed2, 5, "5.00", false},
+		{fixed2, -0.001, "0.00", false},
+		{french, 1234567.891, "1 234 567,89", false},
+		{NumberFormat{Locale: LocaleGerman, MaxFraction: 1}, 1234.56, "1.234,6", false},
+		{NumberFormat{MinFraction: 1, MaxFraction: 3}, 0.5, "0.5", false},
+		{NumberFormat{MinFraction: 1, MaxFraction: 3}, 1.23456, "1.235", false},
+		{NumberFormat{Whole: true}, 2.5, "3", false},
+		{NumberFormat{Whole: true, MaxFraction: 2}, -0.4, "0", false},
+		{NumberFormat{}, 1234567.891, "1234567.891", false},
+		{NumberFormat{MaxFraction: -1}, 0.0012345, "0.0012345", false},
+		{NumberFormat{Significant: 3}, 1234567.891, "1230000", false},
+		{NumberFormat{Significant: 3}, 0.00123456, "0.00123", false},
+		{NumberFormat{Significant: 2}, 0.0012345, "0.0012", false},
+		{NumberFormat{Significant: 2}, 9960, "10000", false},
+		{NumberFormat{Significant: 3, MinFraction: 2}, 1.5, "1.50", false},
+		{english, "1234567", "1,234,567", false},
+		{english, "1234.5", "1,234.5", false},
+		{english, math.Inf(-1), "-Inf", false},
+		// errors
+		{english, "abc", "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := FormatNumberE(test.input, test.format)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestCasterNumberFormat(t *testing.T) {
+	c := &Caster{NumberFormat: &NumberFormat{Locale: LocaleSwiss, MaxFraction: 2}}
+
+	s, err := c.StringE(1234567.891)
+	assert.NoError(t, err)
+	assert.Equal(t, "1'234'567.89", s)
+
+	s, err = c.StringE(uint16(65535))
+	assert.NoError(t, err)
+	assert.Equal(t, "65'535", s)
+
+	s, err = c.StringE("1234")
+	assert.NoError(t, err)
+	assert.Equal(t, "1234", s)
+}
+
+func TestUnicodeDigits(t *testing.T) {
+	c := &Caster{UnicodeDigits: true}
+
+	tests := []struct {
+		input  string
+		expect int64
+	}{
+		{"１２３", 123},
+		{"－５", -5},
+		{"−5", -5},
+		{"٤٢", 42},                   // Arabic-Indic
+		{"۱۲۳", 123},                 // extended Arabic-Indic
+		{"४२", 42},                   // Devanagari
+		{"\U0001D7D9\U0001D7E0", 18}, // mathematical double-struck
+		{" 42　", 42},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := c.Int64E(test.input)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		_, err = Int64E(test.input)
+		assert.Error(t, err, errmsg)
+	}
+
+	f, err := c.Float64E("٣٫٥")
+	assert.NoError(t, err)
+	assert.Equal(t, 3.5, f)
+
+	u, err := c.Uint8E("２５５")
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(255), u)
+
+	d, err := c.DurationE("１．５ｓ")
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	tm, err := c.TimeE("２０２４-０１-０２")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), tm)
+
+	date, err := c.DateE("٢٠٢٤-٠١-٠٢")
+	assert.NoError(t, err)
+	assert.Equal(t, Date{2024, time.January, 2}, date)
+
+	tod, err := c.TimeOfDayE("１３:０５")
+	assert.NoError(t, err)
+	assert.Equal(t, TimeOfDay{Hour: 13, Minute: 5}, tod)
+
+	_, err = c.Int64E("x")
+	assert.Error(t, err)
+}
+
+func TestIntegerNotation(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect int64
+		iserr  bool
+	}{
+		{"1_000_000", 1000000, false},
+		{"1e6", 1000000, false},
+		{"1E6", 1000000, false},
+		{"-2.5e3", -2500, false},
+		{"8.0", 8, false},
+		{"+8.000", 8, false},
+		{"15e-1", 0, true},
+		{"1_000.0", 1000, false},
+		{"1.5e1", 15, false},
+		{"9223372036854775807.0", 9223372036854775807, false},
+		{"-9223372036854775808.0", -9223372036854775808, false},
+		{"0x1F", 31, false},
+		// errors
+		{"1.5", 0, true},
+		{"1e-3", 0, true},
+		{"9223372036854775808.0", 0, true},
+		{"1e1000000", 0, true},
+		{"1__0", 0, true},
+		{"_1.0", 0, true},
+		{"1.0_", 0, true},
+		{"1e", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := Int64E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	_, err := IntE("1.5")
+	assert.True(t, errors.Is(err, ErrLostFraction))
+	_, err = Int64E("1e30")
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+
+	u, err := Uint32E("4e9")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(4000000000), u)
+	_, err = Uint32E("5e9")
+	assert.Error(t, err)
+	_, err = Uint64E("-1.0")
+	assert.Error(t, err)
+
+	f, err := Float64E("1_000.5")
+	assert.NoError(t, err)
+	assert.Equal(t, 1000.5, f)
+	_, err = Float64E("1__0.5")
+	assert.Error(t, err)
+}
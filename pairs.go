@@ -0,0 +1,112 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	defaultPairSeparators     = ",;"
+	defaultKeyValueSeparators = "=:"
+)
+
+// pairsE reads key/value pairs such as "a=1,b=2" from a string, or from
+// each element of a []string as given by repeated flags, into a map. It
+// reports false for other inputs, including strings holding a JSON object
+// or nothing, which are left to JSON. Repeated keys keep their last value,
+// unless multi is set, in which case every key maps to a []string of all
+// its values.
+func (c *Caster) pairsE(i interface{}, multi bool) (map[string]interface{}, bool, error) {
+	var srcs []string
+	switch v := i.(type) {
+	case string:
+		if t := strings.TrimSpace(v); t == "" || t[0] == '{' {
+			return nil, false, nil
+		}
+		srcs = []string{v}
+	case []string:
+		srcs = v
+	default:
+		return nil, false, nil
+	}
+
+	m := map[string]interface{}{}
+	n := 0
+	for _, s := range srcs {
+		if err := c.checkLen(s); err != nil {
+			return nil, true, err
+		}
+		pairs, err := c.splitPairs(s)
+		if err != nil {
+			return nil, true, err
+		}
+		n += len(pairs)
+		if err := c.checkElements(n); err != nil {
+			return nil, true, err
+		}
+		for _, p := range pairs {
+			if !multi {
+				m[p[0]] = p[1]
+				continue
+			}
+			vals, _ := m[p[0]].([]string)
+			m[p[0]] = append(vals, p[1])
+		}
+	}
+	return m, true, nil
+}
+
+// splitPairs splits s into key/value pairs on c.PairSeparators and
+// c.KeyValueSeparators, quoting and escaping as splitFields does. A pair
+// ends at its first unquoted key/value separator, so "url=http://x" keeps
+// its colon, and "==" reads as "=" to accept equality label selectors. The
+// other selectors, "!=" and set-based ones such as "env in (a,b)", name no
+// single value and fail.
+func (c *Caster) splitPairs(s string) ([][2]string, error) {
+	pairSeps, kvSeps := c.PairSeparators, c.KeyValueSeparators
+	if pairSeps == "" {
+		pairSeps = defaultPairSeparators
+	}
+	if kvSeps == "" {
+		kvSeps = defaultKeyValueSeparators
+	}
+	softPairs := strings.IndexFunc(pairSeps, unicode.IsSpace) >= 0
+	endsPair := func(r rune) bool {
+		return r == 0 || strings.ContainsRune(pairSeps, r) || r == ' ' && softPairs
+	}
+
+	fields, ends := scanFields(s, pairSeps+kvSeps)
+	var pairs [][2]string
+	for j := 0; j < len(fields); j++ {
+		if endsPair(ends[j]) {
+			if fields[j] == "" {
+				continue
+			}
+			return nil, fmt.Errorf("%s is not a key/value pair", c.value(fields[j]))
+		}
+
+		key, sep := fields[j], ends[j]
+		if sep == '=' && strings.HasSuffix(key, "!") {
+			return nil, fmt.Errorf("%s is not a key/value pair", c.value(key+"="))
+		}
+		if j+2 < len(fields) && sep == '=' && fields[j+1] == "" && ends[j+1] == '=' {
+			j++
+		}
+		var value strings.Builder
+		for j++; j < len(fields); j++ {
+			value.WriteString(fields[j])
+			if endsPair(ends[j]) {
+				break
+			}
+			value.WriteRune(ends[j])
+		}
+		pairs = append(pairs, [2]string{key, value.String()})
+	}
+	return pairs, nil
+}
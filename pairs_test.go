@@ -0,0 +1,100 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPairs(t *testing.T) {
+	tests := []struct {
+		input  string
+		expect [][2]string
+		iserr  bool
+	}{
+		{"a=1,b=2", [][2]string{{"a", "1"}, {"b", "2"}}, false},
+		{"a:1;b:2", [][2]string{{"a", "1"}, {"b", "2"}}, false},
+		{" a = 1 , b = 2 ,", [][2]string{{"a", "1"}, {"b", "2"}}, false},
+		{"app==web,tier=db", [][2]string{{"app", "web"}, {"tier", "db"}}, false},
+		{"url=http://x:80/p", [][2]string{{"url", "http://x:80/p"}}, false},
+		{`"a=b"='x, y',c=d\,e`, [][2]string{{"a=b", "x, y"}, {"c", "d,e"}}, false},
+		{"empty=,x=1", [][2]string{{"empty", ""}, {"x", "1"}}, false},
+		// errors
+		{"a=1,b", nil, true},
+		{"novalue", nil, true},
+		{"env!=prod", nil, true},
+		{"tier=db, env != prod", nil, true},
+		{"env in (prod, dev)", nil, true},
+		{"env notin (qa)", nil, true},
+		{"!canary", nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := std.splitPairs(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestStringMapPairs(t *testing.T) {
+	ss, err := StringMapStringE("a=1,b=2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, ss)
+
+	ss, err = StringMapStringE([]string{"a=1", "b=2", "a=3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "3", "b": "2"}, ss)
+
+	multi, err := StringMapStringSliceE([]string{"a=1", "b=2", "a=3"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{"a": {"1", "3"}, "b": {"2"}}, multi)
+
+	bools, err := StringMapBoolE("debug:on;trace:no")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"debug": true, "trace": false}, bools)
+
+	ints, err := StringMapIntE("a=1,b=2")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, ints)
+
+	int64s, err := StringMapInt64E("a=1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"a": 1}, int64s)
+
+	anys, err := StringMapE("a=1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"a": "1"}, anys)
+
+	generic, err := MapE[string, float64]("a=1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]float64{"a": 1.5}, generic)
+
+	c := &Caster{PairSeparators: " ", KeyValueSeparators: "="}
+	ss, err = c.StringMapStringE(`a=1 b="x y" c=http://h:1`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "x y", "c": "http://h:1"}, ss)
+
+	// JSON objects still decode as JSON
+	ss, err = StringMapStringE(`{"a": "1"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1"}, ss)
+
+	// errors
+	_, err = StringMapStringE("a")
+	assert.Error(t, err)
+	_, err = StringMapE([]string{"a=1", "b"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,220 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxRangeLen caps how many numbers a range list may expand to when
+// Caster.MaxRangeLen is zero.
+const DefaultMaxRangeLen = 1 << 16
+
+// IntRangeE casts a range list such as "8000-8010,9000" or "0-100:10" to
+// a []int type.
+func IntRangeE(i interface{}) ([]int, error) {
+	return std.IntRangeE(i)
+}
+
+// IntRangeE casts a range list such as "8000-8010,9000" or "0-100:10" to
+// a []int type.
+func (c *Caster) IntRangeE(i interface{}) ([]int, error) {
+	a, err := c.rangeE(i, math.MinInt64>>(64-strconv.IntSize), math.MaxInt64>>(64-strconv.IntSize), "[]int")
+	if err != nil || a == nil {
+		return nil, err
+	}
+	v := make([]int, len(a))
+	for j, n := range a {
+		v[j] = int(n)
+	}
+	return v, nil
+}
+
+// Int64RangeE casts a range list such as "8000-8010,9000" or "0-100:10"
+// to a []int64 type.
+func Int64RangeE(i interface{}) ([]int64, error) {
+	return std.Int64RangeE(i)
+}
+
+// Int64RangeE casts a range list such as "8000-8010,9000" or "0-100:10"
+// to a []int64 type.
+func (c *Caster) Int64RangeE(i interface{}) ([]int64, error) {
+	return c.rangeE(i, math.MinInt64, math.MaxInt64, "[]int64")
+}
+
+// Uint16RangeE casts a range list such as "8000-8010,9000" or "0-100:10"
+// to a []uint16 type, as used for ports.
+func Uint16RangeE(i interface{}) ([]uint16, error) {
+	return std.Uint16RangeE(i)
+}
+
+// Uint16RangeE casts a range list such as "8000-8010,9000" or "0-100:10"
+// to a []uint16 type, as used for ports.
+func (c *Caster) Uint16RangeE(i interface{}) ([]uint16, error) {
+	a, err := c.rangeE(i, 0, math.MaxUint16, "[]uint16")
+	if err != nil || a == nil {
+		return nil, err
+	}
+	v := make([]uint16, len(a))
+	for j, n := range a {
+		v[j] = uint16(n)
+	}
+	return v, nil
+}
+
+// FormatRangeE casts an interface to a []int64 type and compresses it into
+// range list notation, sorted and without duplicates, so that
+// []int{3, 1, 2, 8} becomes "1-3,8".
+func FormatRangeE(i interface{}) (string, error) {
+	return std.FormatRangeE(i)
+}
+
+// FormatRangeE casts an interface to a []int64 type and compresses it into
+// range list notation, sorted and without duplicates, so that
+// []int{3, 1, 2, 8} becomes "1-3,8".
+func (c *Caster) FormatRangeE(i interface{}) (string, error) {
+	a, err := c.Int64SliceE(i)
+	if err != nil {
+		return "", err
+	}
+	a = append([]int64(nil), a...)
+	sort.Slice(a, func(x, y int) bool { return a[x] < a[y] })
+
+	var b strings.Builder
+	for j := 0; j < len(a); {
+		k := j
+		for k+1 < len(a) && (a[k+1] == a[k] || a[k+1] == a[k]+1) {
+			k++
+		}
+		if b.Len() > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatInt(a[j], 10))
+		if a[k] != a[j] {
+			b.WriteByte('-')
+			b.WriteString(strconv.FormatInt(a[k], 10))
+		}
+		j = k + 1
+	}
+	return b.String(), nil
+}
+
+// rangeE expands the comma separated range list i into the numbers it
+// names, each within [min, max]. Slices are read element by element and
+// numbers taken as they are.
+func (c *Caster) rangeE(i interface{}, min, max int64, to string) ([]int64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return nil, c.nilE(to)
+	}
+
+	var parts []string
+	switch v := reflect.ValueOf(i); {
+	case v.Kind() == reflect.String:
+		parts = strings.Split(v.String(), ",")
+	case v.Kind() == reflect.Slice || v.Kind() == reflect.Array:
+		for j := 0; j < v.Len(); j++ {
+			s, err := c.StringE(v.Index(j).Interface())
+			if err != nil {
+				return nil, c.castE(i, to)
+			}
+			parts = append(parts, strings.Split(s, ",")...)
+		}
+	default:
+		s, err := c.StringE(i)
+		if err != nil {
+			return nil, c.castE(i, to)
+		}
+		parts = []string{s}
+	}
+
+	limit := c.MaxRangeLen
+	if limit <= 0 {
+		limit = DefaultMaxRangeLen
+	}
+
+	var a []int64
+	seen := map[int64]bool{}
+	for _, part := range parts {
+		lo, hi, step, err := c.parseRange(strings.TrimSpace(part), min, max)
+		if err != nil {
+			return nil, c.castEf(i, to, "%w", err)
+		}
+		// Work in uint64, as hi-lo overflows an int64 for wide spans.
+		span := uint64(hi) - uint64(lo)
+		if span/uint64(step) >= uint64(limit-len(a)) {
+			return nil, c.castEf(i, to, "expands to more than %d numbers", limit)
+		}
+		for k := uint64(0); ; k += uint64(step) {
+			n := int64(uint64(lo) + k)
+			if seen[n] {
+				return nil, c.castEf(i, to, "%s is listed twice", c.value(n))
+			}
+			seen[n] = true
+			a = append(a, n)
+			if span-k < uint64(step) {
+				break
+			}
+		}
+	}
+	return a, nil
+}
+
+// parseRange parses "n", "lo-hi" or "lo-hi:step", rendering s in its
+// errors as c.value does. Digits are read as c.UnicodeDigits says.
+func (c *Caster) parseRange(s string, min, max int64) (lo, hi, step int64, err error) {
+	s = c.ascii(s)
+	step = 1
+	if j := strings.LastIndexByte(s, ':'); j >= 0 {
+		if step, err = strconv.ParseInt(strings.TrimSpace(s[j+1:]), 10, 64); err != nil || step <= 0 {
+			return 0, 0, 0, fmt.Errorf("bad step in %s", c.value(s))
+		}
+		s = s[:j]
+	}
+
+	// Skip a leading minus sign so that "-5--1" splits after "-5".
+	j := -1
+	if len(s) > 1 {
+		if j = strings.IndexByte(s[1:], '-'); j >= 0 {
+			j++
+		}
+	}
+	if j < 0 {
+		if step != 1 {
+			return 0, 0, 0, fmt.Errorf("step without a range in %s", c.value(s))
+		}
+		lo, err = c.parseBounded(s, min, max)
+		return lo, lo, 1, err
+	}
+
+	if lo, err = c.parseBounded(s[:j], min, max); err != nil {
+		return 0, 0, 0, err
+	}
+	if hi, err = c.parseBounded(s[j+1:], min, max); err != nil {
+		return 0, 0, 0, err
+	}
+	if hi < lo {
+		return 0, 0, 0, fmt.Errorf("reversed range %s", c.value(s))
+	}
+	return lo, hi, step, nil
+}
+
+func (c *Caster) parseBounded(s string, min, max int64) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %s", c.value(s))
+	}
+	if n < min || n > max {
+		return 0, fmt.Errorf("%s is out of range [%d, %d]", c.value(n), min, max)
+	}
+	return n, nil
+}
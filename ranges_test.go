@@ -0,0 +1,134 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntRangeE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect []int
+		iserr  bool
+	}{
+		{"1-5,8,10-12", []int{1, 2, 3, 4, 5, 8, 10, 11, 12}, false},
+		{" 9000 , 8000-8002 ", []int{9000, 8000, 8001, 8002}, false},
+		{"0-100:25", []int{0, 25, 50, 75, 100}, false},
+		{"0-10:4", []int{0, 4, 8}, false},
+		{"-5--3,-1", []int{-5, -4, -3, -1}, false},
+		{"7", []int{7}, false},
+		{[]string{"1-2", "4"}, []int{1, 2, 4}, false},
+		{[]int{3, 1}, []int{3, 1}, false},
+		{5, []int{5}, false},
+		{nil, nil, false},
+		// errors
+		{"1-5,3-8", nil, true},
+		{"1,1", nil, true},
+		{"5-1", nil, true},
+		{"1-9999999999", nil, true},
+		{"0-10:0", nil, true},
+		{"5:2", nil, true},
+		{"1-x", nil, true},
+		{"", nil, true},
+		{testing.T{}, nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := IntRangeE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = IntRange(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestTypedRangeE(t *testing.T) {
+	ports, err := Uint16RangeE("8000-8002,9000")
+	assert.NoError(t, err)
+	assert.Equal(t, []uint16{8000, 8001, 8002, 9000}, ports)
+	assert.Equal(t, ports, Uint16Range("8000-8002,9000"))
+
+	_, err = Uint16RangeE("65530-65536")
+	assert.Error(t, err)
+	_, err = Uint16RangeE("-1")
+	assert.Error(t, err)
+
+	big, err := Int64RangeE("9223372036854775806-9223372036854775807")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9223372036854775806, 9223372036854775807}, big)
+	assert.Equal(t, big, Int64Range("9223372036854775806-9223372036854775807"))
+
+	// The full int64 span overflows hi-lo.
+	_, err = Int64RangeE("-9223372036854775808-9223372036854775807")
+	assert.Error(t, err)
+	wide, err := Int64RangeE("-9223372036854775808-9223372036854775807:9223372036854775807")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{-9223372036854775808, -1, 9223372036854775806}, wide)
+
+	c := &Caster{MaxRangeLen: 3}
+	_, err = c.IntRangeE("1-3")
+	assert.NoError(t, err)
+	_, err = c.IntRangeE("1-3,5")
+	assert.Error(t, err)
+
+	c = &Caster{UnicodeDigits: true}
+	digits, err := c.IntRangeE("１-３,５－６：１")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 5, 6}, digits)
+	_, err = IntRangeE("１-３")
+	assert.Error(t, err)
+}
+
+func TestFormatRangeE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect string
+		iserr  bool
+	}{
+		{[]int{1, 2, 3, 4, 5, 8, 10, 11, 12}, "1-5,8,10-12", false},
+		{[]int{12, 3, 1, 2, 2}, "1-3,12", false},
+		{[]int64{-3, -2, 0}, "-3--2,0", false},
+		{[]uint16{8000, 8001}, "8000-8001", false},
+		{[]int{}, "", false},
+		{"5 3 4", "3-5", false},
+		// errors
+		{testing.T{}, "", true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := FormatRangeE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = FormatRange(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	round, err := IntRangeE(FormatRange([]int{1, 2, 3, 7, 9, 10}))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 7, 9, 10}, round)
+}
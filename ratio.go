@@ -0,0 +1,201 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var errZeroDenominator = errors.New("zero denominator")
+
+// ratE casts i to an exact number. Strings may be fractions such as "3/4"
+// or ratios such as "1:3", and may end in "%" or "‰"; bare reports
+// whether they were plain numbers, which the caller gives its own unit.
+func (c *Caster) ratE(i interface{}, to string) (r *big.Rat, bare bool, err error) {
+	if v, ok := i.(*big.Rat); ok && v != nil {
+		return new(big.Rat).Set(v), true, nil
+	}
+	i = indirect(i)
+
+	switch v := i.(type) {
+	case big.Rat:
+		return new(big.Rat).Set(&v), true, nil
+	case uint, uint64, uint32, uint16, uint8:
+		n, _ := c.Uint64E(v)
+		return new(big.Rat).SetInt(new(big.Int).SetUint64(n)), true, nil
+	case int, int64, int32, int16, int8:
+		n, _ := c.Int64E(v)
+		return new(big.Rat).SetInt64(n), true, nil
+	case string:
+		r, bare, err := c.parseRatio(v)
+		if err != nil {
+			return nil, false, c.parseE(i, to, err)
+		}
+		return r, bare, nil
+	}
+
+	f, err := c.Float64E(i)
+	if err != nil {
+		return nil, false, err
+	}
+	if r = new(big.Rat).SetFloat64(f); r == nil {
+		return nil, false, c.castE(i, to)
+	}
+	return r, true, nil
+}
+
+// parseRatio parses s as ratE describes.
+func (c *Caster) parseRatio(s string) (*big.Rat, bool, error) {
+	s = strings.TrimSpace(c.ascii(s))
+
+	scale := int64(1)
+	switch {
+	case strings.HasSuffix(s, "%"):
+		s, scale = strings.TrimSuffix(s, "%"), 100
+	case strings.HasSuffix(s, "‰"):
+		s, scale = strings.TrimSuffix(s, "‰"), 1000
+	}
+	num, den := s, ""
+	if j := strings.IndexAny(s, "/:"); j >= 0 {
+		num, den = s[:j], s[j+1:]
+	}
+
+	r, err := c.parseExact(num)
+	if err != nil {
+		return nil, false, err
+	}
+	if den != "" {
+		d, err := c.parseExact(den)
+		if err != nil {
+			return nil, false, err
+		}
+		if d.Sign() == 0 {
+			return nil, false, errZeroDenominator
+		}
+		r.Quo(r, d)
+	}
+	r.Quo(r, new(big.Rat).SetInt64(scale))
+	return r, den == "" && scale == 1, nil
+}
+
+// parseExact parses the decimal number s, written in c.Locale, exactly.
+func (c *Caster) parseExact(s string) (*big.Rat, error) {
+	s, err := c.Locale.normalize(strings.TrimSpace(s))
+	if err != nil {
+		return nil, err
+	}
+	return parseRat(s)
+}
+
+// RatE casts an interface to a *big.Rat type. Strings may be fractions
+// such as "3/4", ratios such as "1:3", percentages such as "45%" and
+// per-mille values such as "5‰".
+func RatE(i interface{}) (*big.Rat, error) {
+	return std.RatE(i)
+}
+
+// RatE casts an interface to a *big.Rat type. Strings may be fractions
+// such as "3/4", ratios such as "1:3", percentages such as "45%" and
+// per-mille values such as "5‰".
+func (c *Caster) RatE(i interface{}) (*big.Rat, error) {
+	if isNil(i) {
+		return nil, c.nilE("*big.Rat")
+	}
+	r, _, err := c.ratE(i, "*big.Rat")
+	return r, err
+}
+
+// RatioE casts an interface to a float64 type as RatE does, so that "45%"
+// and "9/20" are both 0.45.
+func RatioE(i interface{}) (float64, error) {
+	return std.RatioE(i)
+}
+
+// RatioE casts an interface to a float64 type as RatE does, so that "45%"
+// and "9/20" are both 0.45.
+func (c *Caster) RatioE(i interface{}) (float64, error) {
+	if isNil(i) {
+		return 0, c.nilE("ratio")
+	}
+	r, _, err := c.ratE(i, "ratio")
+	if err != nil {
+		return 0, err
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
+
+// RatioInE casts an interface to a float64 type as RatioE does and
+// checks that it lies between min and max, so that RatioInE(i, 0, 1)
+// accepts 0% to 100%.
+func RatioInE(i interface{}, min, max float64) (float64, error) {
+	return std.RatioInE(i, min, max)
+}
+
+// RatioInE casts an interface to a float64 type as RatioE does and
+// checks that it lies between min and max, so that RatioInE(i, 0, 1)
+// accepts 0% to 100%.
+func (c *Caster) RatioInE(i interface{}, min, max float64) (float64, error) {
+	if isNil(i) {
+		return 0, c.nilE("ratio")
+	}
+	f, err := c.RatioE(i)
+	if err != nil {
+		return 0, err
+	}
+	if f < min || f > max {
+		return 0, c.castEf(i, "ratio", "%v is out of range [%v, %v]", f, min, max)
+	}
+	return f, nil
+}
+
+// PercentE casts an interface to a float64 type counted in percent.
+// Plain numbers are taken as percentages already, while others are
+// converted, so that "45", "45%", "450‰" and "9/20" are all 45.
+func PercentE(i interface{}) (float64, error) {
+	return std.PercentE(i)
+}
+
+// PercentE casts an interface to a float64 type counted in percent.
+// Plain numbers are taken as percentages already, while others are
+// converted, so that "45", "45%", "450‰" and "9/20" are all 45.
+func (c *Caster) PercentE(i interface{}) (float64, error) {
+	if isNil(i) {
+		return 0, c.nilE("percent")
+	}
+	r, bare, err := c.ratE(i, "percent")
+	if err != nil {
+		return 0, err
+	}
+	if !bare {
+		r.Mul(r, big.NewRat(100, 1))
+	}
+	f, _ := r.Float64()
+	return f, nil
+}
+
+// FormatPercentE casts an interface to a ratio as RatioE does and writes
+// it as a percentage with at most digits fraction digits, such as "12.5%".
+func FormatPercentE(i interface{}, digits int) (string, error) {
+	return std.FormatPercentE(i, digits)
+}
+
+// FormatPercentE casts an interface to a ratio as RatioE does and writes
+// it as a percentage with at most digits fraction digits, such as "12.5%".
+func (c *Caster) FormatPercentE(i interface{}, digits int) (string, error) {
+	if isNil(i) {
+		return "", c.nilE("percent")
+	}
+	r, _, err := c.ratE(i, "percent")
+	if err != nil {
+		return "", err
+	}
+	f, _ := r.Mul(r, big.NewRat(100, 1)).Float64()
+	s, _ := NumberFormat{MaxFraction: digits, Whole: digits == 0}.format(f)
+	return s + "%", nil
+}
@@ -0,0 +1,124 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatioE(t *testing.T) {
+	tests := []struct {
+		input   interface{}
+		ratio   float64
+		percent float64
+		iserr   bool
+	}{
+		{"45%", 0.45, 45, false},
+		{"12.5%", 0.125, 12.5, false},
+		{" 12.5 % ", 0.125, 12.5, false},
+		{"5‰", 0.005, 0.5, false},
+		{"3/4", 0.75, 75, false},
+		{"1:4", 0.25, 25, false},
+		{"-1/8", -0.125, -12.5, false},
+		{"1.5/3", 0.5, 50, false},
+		{"0.45", 0.45, 0.45, false},
+		{"45", 45, 45, false},
+		{0.25, 0.25, 0.25, false},
+		{3, 3, 3, false},
+		{big.NewRat(1, 3), 1.0 / 3, 1.0 / 3, false},
+		// errors
+		{"1/0", 0, 0, true},
+		{"%", 0, 0, true},
+		{"1/2/3", 0, 0, true},
+		{"abc%", 0, 0, true},
+		{"4%%", 0, 0, true},
+		{[]int{1}, 0, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := RatioE(test.input)
+		p, perr := PercentE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			assert.Error(t, perr, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.ratio, v, errmsg)
+		assert.NoError(t, perr, errmsg)
+		assert.Equal(t, test.percent, p, errmsg)
+	}
+}
+
+func TestRatE(t *testing.T) {
+	r, err := RatE("1/3")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/3", r.String())
+
+	r, err = RatE("12.5%")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/8", r.String())
+
+	r, err = (&Caster{Locale: LocaleGerman}).RatE("2,5:10")
+	assert.NoError(t, err)
+	assert.Equal(t, "1/4", r.String())
+
+	r, err = RatE(uint64(1<<63 + 1))
+	assert.NoError(t, err)
+	assert.Equal(t, "9223372036854775809/1", r.String())
+
+	in := big.NewRat(2, 3)
+	r, err = RatE(in)
+	assert.NoError(t, err)
+	r.Add(r, r)
+	assert.Equal(t, "2/3", in.String())
+
+	var nilRat *big.Rat
+	r, err = RatE(nilRat)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestRatioInE(t *testing.T) {
+	v, err := RatioInE("100%", 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, v)
+
+	_, err = RatioInE("101%", 0, 1)
+	assert.EqualError(t, err, `unable to cast "101%" of type string to ratio: 1.01 is out of range [0, 1]`)
+	_, err = RatioInE("-1/2", 0, 1)
+	assert.Error(t, err)
+}
+
+func TestFormatPercentE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		digits int
+		expect string
+	}{
+		{0.125, 1, "12.5%"},
+		{0.125, 0, "13%"},
+		{"3/4", 2, "75%"},
+		{"1/3", 2, "33.33%"},
+		{0.07, 2, "7%"},
+		{"5‰", 1, "0.5%"},
+		{1, 0, "100%"},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := FormatPercentE(test.input, test.digits)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
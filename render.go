@@ -0,0 +1,167 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxValueLen caps how many bytes of an input value error messages
+// show when Caster.MaxValueLen is zero.
+const DefaultMaxValueLen = 256
+
+// Redacted stands in for hidden values in error messages.
+const Redacted = "[REDACTED]"
+
+// RedactKeys returns a hook for Caster.Redact that hides the values under
+// keys and fields containing any of words, ignoring case.
+func RedactKeys(words ...string) func(key string) bool {
+	return func(key string) bool {
+		key = strings.ToLower(key)
+		for _, w := range words {
+			if strings.Contains(key, strings.ToLower(w)) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// castE returns the error for failing to cast i to the type named to.
+func (c *Caster) castE(i interface{}, to string) error {
+	return fmt.Errorf("unable to cast %s of type %T to %s", c.value(i), i, to)
+}
+
+// castEf is castE with a detail, formatted as by fmt.Errorf, appended.
+func (c *Caster) castEf(i interface{}, to string, format string, a ...interface{}) error {
+	return fmt.Errorf("unable to cast %s of type %T to %s: "+format, append([]interface{}{c.value(i), i, to}, a...)...)
+}
+
+// value renders i for an error message the way %#v does, hiding what
+// c.Redact asks for and cutting it short after c.MaxValueLen bytes.
+func (c *Caster) value(i interface{}) string {
+	if c.OmitValues {
+		return "value"
+	}
+	var s string
+	if c.Redact == nil {
+		s = fmt.Sprintf("%#v", i)
+	} else {
+		var b strings.Builder
+		c.writeValue(&b, reflect.ValueOf(i), map[uintptr]bool{})
+		s = b.String()
+	}
+
+	max := c.MaxValueLen
+	if max == 0 {
+		max = DefaultMaxValueLen
+	}
+	if max < 0 || len(s) <= max {
+		return s
+	}
+	for max > 0 && !utf8.RuneStart(s[max]) {
+		max--
+	}
+	return s[:max] + "..."
+}
+
+// writeValue writes v to b as %#v would, with Redacted in place of the
+// map values and struct fields c.Redact hides. Pointers are followed, and
+// those in seen, already being written, are written as addresses.
+func (c *Caster) writeValue(b *strings.Builder, v reflect.Value, seen map[uintptr]bool) {
+	switch v.Kind() {
+	case reflect.Interface:
+		if !v.IsNil() {
+			c.writeValue(b, v.Elem(), seen)
+			return
+		}
+	case reflect.Ptr:
+		if v.IsNil() {
+			break
+		}
+		if seen[v.Pointer()] {
+			fmt.Fprintf(b, "(%s)(%#x)", v.Type(), v.Pointer())
+			return
+		}
+		seen[v.Pointer()] = true
+		defer delete(seen, v.Pointer())
+		b.WriteString("&")
+		c.writeValue(b, v.Elem(), seen)
+		return
+	case reflect.Map:
+		if v.IsNil() {
+			break
+		}
+		keys := v.MapKeys()
+		sort.Slice(keys, func(x, y int) bool {
+			return fmt.Sprint(keys[x]) < fmt.Sprint(keys[y])
+		})
+		b.WriteString(v.Type().String() + "{")
+		for j, k := range keys {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			fmt.Fprintf(b, "%#v:", k)
+			if c.Redact(fmt.Sprint(k)) {
+				b.WriteString(Redacted)
+			} else {
+				c.writeValue(b, v.MapIndex(k), seen)
+			}
+		}
+		b.WriteString("}")
+		return
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			break
+		}
+		b.WriteString(v.Type().String() + "{")
+		for j := 0; j < v.Len(); j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			c.writeValue(b, v.Index(j), seen)
+		}
+		b.WriteString("}")
+		return
+	case reflect.Struct:
+		b.WriteString(v.Type().String() + "{")
+		for j := 0; j < v.NumField(); j++ {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			name := v.Type().Field(j).Name
+			b.WriteString(name + ":")
+			if c.Redact(name) {
+				b.WriteString(Redacted)
+			} else {
+				c.writeValue(b, v.Field(j), seen)
+			}
+		}
+		b.WriteString("}")
+		return
+	case reflect.Invalid:
+		b.WriteString("<nil>")
+		return
+	}
+	fmt.Fprintf(b, "%#v", v)
+}
+
+// redactedError hides the message of an error about a redacted value.
+type redactedError struct {
+	err error
+}
+
+func (e redactedError) Error() string {
+	return Redacted
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,128 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorValueRendering(t *testing.T) {
+	type login struct {
+		User     string
+		Password string
+	}
+	redact := RedactKeys("password", "TOKEN")
+	long := strings.Repeat("é", 200)
+
+	tests := []struct {
+		c      *Caster
+		input  interface{}
+		expect string
+	}{
+		{&Caster{}, "x", `unable to cast "x" of type string to int: invalid syntax`},
+		{&Caster{}, long, `unable to cast "` + strings.Repeat("é", 127) + `... of type string to int: invalid syntax`},
+		{&Caster{MaxValueLen: 4}, "abcdef", `unable to cast "abc... of type string to int: invalid syntax`},
+		{&Caster{MaxValueLen: -1}, long, `unable to cast "` + long + `" of type string to int: invalid syntax`},
+		{&Caster{OmitValues: true}, "secret", `unable to cast value of type string to int: invalid syntax`},
+		{&Caster{Redact: redact}, map[string]interface{}{"user": "bob", "api_token": "t0k", "n": []int{1}},
+			`unable to cast map[string]interface {}{"api_token":[REDACTED], "n":[]int{1}, "user":"bob"} of type map[string]interface {} to int`},
+		{&Caster{Redact: redact}, login{"bob", "hunter2"},
+			`unable to cast to.login{User:"bob", Password:[REDACTED]} of type to.login to int`},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		_, err := test.c.IntE(test.input)
+		assert.EqualError(t, err, test.expect, errmsg)
+	}
+
+	c := &Caster{Redact: redact}
+	_, err := c.StringMapIntE(map[string]string{"password": "hunter2"})
+	assert.EqualError(t, err, `unable to cast key "password" to map[string]int: [REDACTED]`)
+	_, err = c.StringMapIntE("password=hunter2")
+	assert.NotContains(t, err.Error(), "hunter2")
+	_, err = c.StringMapIntE(map[string]string{"n": "x"})
+	assert.EqualError(t, err, `unable to cast key "n" to map[string]int: unable to cast "x" of type string to int: invalid syntax`)
+
+	_, err = (&Caster{OmitValues: true}).StringMapStringE(`{"password": "hunter2"`)
+	assert.NotContains(t, err.Error(), "hunter2")
+
+	// Keys are bounded like values.
+	short := &Caster{MaxValueLen: 8}
+	_, err = short.StringMapIntE(map[string]string{long: "1", "n": "x"})
+	assert.EqualError(t, err, `unable to cast key "n" to map[string]int: unable to cast "x" of type string to int: invalid syntax`)
+	_, err = short.StringMapIntE(map[string]string{long: "x"})
+	assert.EqualError(t, err, `unable to cast key "ééé... to map[string]int: unable to cast "x" of type string to int: invalid syntax`)
+	_, err = short.NormalizeE(map[login]int{{"bob", "hunter2"}: 1})
+	assert.NotContains(t, err.Error(), "hunter2")
+	assert.Contains(t, err.Error(), `unable to normalize key to.login... of`)
+
+	// Pointers are followed, cycles included.
+	_, err = c.IntE(map[string]interface{}{"cfg": &login{"bob", "hunter2"}, "none": (*login)(nil)})
+	assert.EqualError(t, err, `unable to cast map[string]interface {}{"cfg":&to.login{User:"bob", Password:[REDACTED]}, "none":(*to.login)(nil)} of type map[string]interface {} to int`)
+	type node struct {
+		Next     *node
+		Password string
+	}
+	loop := &node{Password: "hunter2"}
+	loop.Next = loop
+	_, err = c.IntE([]*node{loop})
+	assert.Contains(t, err.Error(), `[]*to.node{&to.node{Next:(*to.node)(0x`)
+	assert.NotContains(t, err.Error(), "hunter2")
+}
+
+func TestErrorValueRenderingPaths(t *testing.T) {
+	secret := "hunter2-" + strings.Repeat("x", 300)
+
+	casts := []func(c *Caster, i interface{}) error{
+		func(c *Caster, i interface{}) error { _, err := c.TimeE(i); return err },
+		func(c *Caster, i interface{}) error { _, err := c.DurationE(i); return err },
+		func(c *Caster, i interface{}) error { _, err := c.TimeOfDayE(i); return err },
+		func(c *Caster, i interface{}) error { _, err := c.DateE(i); return err },
+		func(c *Caster, i interface{}) error { _, err := c.IntRangeE(i); return err },
+		func(c *Caster, i interface{}) error { _, err := c.IntRangeE("1-" + i.(string)); return err },
+		func(c *Caster, i interface{}) error { _, err := c.IntRangeE("1-5:" + i.(string)); return err },
+	}
+
+	for i, cast := range casts {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		err := cast(&Caster{}, secret)
+		if assert.Error(t, err, errmsg) {
+			assert.Contains(t, err.Error(), "hunter2", errmsg)
+			assert.NotContains(t, err.Error(), secret, errmsg)
+		}
+
+		err = cast(&Caster{OmitValues: true}, secret)
+		if assert.Error(t, err, errmsg) {
+			assert.NotContains(t, err.Error(), "hunter2", errmsg)
+		}
+	}
+
+	c := &Caster{Redact: RedactKeys("password")}
+	in := map[string]interface{}{"password": "hunter2"}
+	_, err := MapWithE[string, time.Time](c, in)
+	assert.EqualError(t, err, `unable to cast key "password" to map[string]time.Time: [REDACTED]`)
+	_, err = MapWithE[string, time.Duration](c, in)
+	assert.EqualError(t, err, `unable to cast key "password" to map[string]time.Duration: [REDACTED]`)
+	_, err = MapWithE[string, TimeOfDay](c, in)
+	assert.EqualError(t, err, `unable to cast key "password" to map[string]to.TimeOfDay: [REDACTED]`)
+	_, err = c.IntRangeE(in)
+	assert.EqualError(t, err, `unable to cast map[string]interface {}{"password":[REDACTED]} of type map[string]interface {} to []int`)
+
+	_, err = c.TimeE("never")
+	assert.EqualError(t, err, `unable to cast "never" of type string to Time`)
+	_, err = c.DurationE("soon")
+	assert.EqualError(t, err, `unable to cast "soon" of type string to Duration`)
+	_, err = c.Uint16RangeE("1-70000")
+	assert.EqualError(t, err, `unable to cast "1-70000" of type string to []uint16: 70000 is out of range [0, 65535]`)
+}
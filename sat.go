@@ -0,0 +1,263 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// satE casts i to an integer of the given bits and signedness, clamping it
+// to the range of that type; clamped reports whether it had to.
+func (c *Caster) satE(i interface{}, to string, bits int, signed bool) (n *big.Int, clamped bool, err error) {
+	n, err = c.bigIntE(i, to)
+	if err != nil {
+		return new(big.Int), false, err
+	}
+	lo, hi := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		hi.Rsh(hi, 1)
+		lo.Neg(hi)
+	}
+	hi.Sub(hi, big.NewInt(1))
+	switch {
+	case n.Cmp(lo) < 0:
+		return lo, true, nil
+	case n.Cmp(hi) > 0:
+		return hi, true, nil
+	}
+	return n, false, nil
+}
+
+// bigIntE casts i to an integer of any size on the way to the type named
+// to. Floats are truncated, and infinities become numbers beyond the range
+// of every integer type.
+func (c *Caster) bigIntE(i interface{}, to string) (*big.Int, error) {
+	if v, ok := i.(*big.Int); ok && v != nil {
+		return new(big.Int).Set(v), nil
+	}
+	i = indirect(i)
+
+	if isNil(i) {
+		return new(big.Int), c.nilE(to)
+	}
+
+	switch v := i.(type) {
+	case big.Int:
+		return new(big.Int).Set(&v), nil
+	case string:
+		n, err := c.parseBigInt(v)
+		if err != nil {
+			return nil, c.parseE(i, to, err)
+		}
+		return n, nil
+	case bool:
+		if v {
+			return big.NewInt(1), nil
+		}
+		return new(big.Int), nil
+	}
+
+	switch v := reflect.ValueOf(i); v.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		return big.NewInt(v.Int()), nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
+		return new(big.Int).SetUint64(v.Uint()), nil
+	case reflect.Float64, reflect.Float32:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			return nil, c.castEf(i, to, "%w", ErrNaN)
+		case math.IsInf(f, 0):
+			n := new(big.Int).Lsh(big.NewInt(1), 64)
+			if f < 0 {
+				n.Neg(n)
+			}
+			return n, nil
+		case c.Exact && f != math.Trunc(f):
+			return nil, c.castEf(i, to, "%w", ErrInexact)
+		}
+		n, _ := big.NewFloat(f).Int(nil)
+		return n, nil
+	}
+	return nil, c.castE(i, to)
+}
+
+// parseBigInt parses s as parseInt does, whatever its size. Exponents too
+// large to spell out give a number beyond the range of every integer type,
+// or zero when they are negative.
+func (c *Caster) parseBigInt(s string) (*big.Int, error) {
+	s, err := c.Locale.normalize(c.ascii(s))
+	if err != nil {
+		return nil, err
+	}
+	t, base := c.base(s)
+	if n, ok := new(big.Int).SetString(t, base); ok {
+		return n, nil
+	}
+	if !isDecimal(t) {
+		return nil, strconv.ErrSyntax
+	}
+	n, err := parseDecimal(t)
+	if !errors.Is(err, strconv.ErrRange) {
+		return n, err
+	}
+	e := strings.IndexAny(t, "eE")
+	m, err := parseRat(t[:e])
+	if err != nil {
+		return nil, err
+	}
+	if m.Sign() == 0 || t[e+1] == '-' {
+		return new(big.Int), nil
+	}
+	return new(big.Int).Lsh(big.NewInt(int64(m.Sign())), 64), nil
+}
+
+// Int64SatE casts an interface to an int64 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to. Floats
+// are truncated and infinities clamped, but NaN fails.
+func Int64SatE(i interface{}) (int64, bool, error) {
+	return std.Int64SatE(i)
+}
+
+// Int64SatE casts an interface to an int64 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to. Floats
+// are truncated and infinities clamped, but NaN fails.
+func (c *Caster) Int64SatE(i interface{}) (int64, bool, error) {
+	n, clamped, err := c.satE(i, "int64", 64, true)
+	return n.Int64(), clamped, err
+}
+
+// Int32SatE casts an interface to an int32 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to.
+func Int32SatE(i interface{}) (int32, bool, error) {
+	return std.Int32SatE(i)
+}
+
+// Int32SatE casts an interface to an int32 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to.
+func (c *Caster) Int32SatE(i interface{}) (int32, bool, error) {
+	n, clamped, err := c.satE(i, "int32", 32, true)
+	return int32(n.Int64()), clamped, err
+}
+
+// Int16SatE casts an interface to an int16 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to.
+func Int16SatE(i interface{}) (int16, bool, error) {
+	return std.Int16SatE(i)
+}
+
+// Int16SatE casts an interface to an int16 type, clamping numbers beyond
+// its range to the nearest bound and reporting whether it had to.
+func (c *Caster) Int16SatE(i interface{}) (int16, bool, error) {
+	n, clamped, err := c.satE(i, "int16", 16, true)
+	return int16(n.Int64()), clamped, err
+}
+
+// Int8SatE casts an interface to an int8 type, clamping numbers beyond its
+// range to the nearest bound and reporting whether it had to.
+func Int8SatE(i interface{}) (int8, bool, error) {
+	return std.Int8SatE(i)
+}
+
+// Int8SatE casts an interface to an int8 type, clamping numbers beyond its
+// range to the nearest bound and reporting whether it had to.
+func (c *Caster) Int8SatE(i interface{}) (int8, bool, error) {
+	n, clamped, err := c.satE(i, "int8", 8, true)
+	return int8(n.Int64()), clamped, err
+}
+
+// IntSatE casts an interface to an int type, clamping numbers beyond its
+// range to the nearest bound and reporting whether it had to.
+func IntSatE(i interface{}) (int, bool, error) {
+	return std.IntSatE(i)
+}
+
+// IntSatE casts an interface to an int type, clamping numbers beyond its
+// range to the nearest bound and reporting whether it had to.
+func (c *Caster) IntSatE(i interface{}) (int, bool, error) {
+	n, clamped, err := c.satE(i, "int", strconv.IntSize, true)
+	return int(n.Int64()), clamped, err
+}
+
+// UintSatE casts an interface to a uint type, clamping numbers beyond its
+// range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func UintSatE(i interface{}) (uint, bool, error) {
+	return std.UintSatE(i)
+}
+
+// UintSatE casts an interface to a uint type, clamping numbers beyond its
+// range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func (c *Caster) UintSatE(i interface{}) (uint, bool, error) {
+	n, clamped, err := c.satE(i, "uint", strconv.IntSize, false)
+	return uint(n.Uint64()), clamped, err
+}
+
+// Uint64SatE casts an interface to a uint64 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func Uint64SatE(i interface{}) (uint64, bool, error) {
+	return std.Uint64SatE(i)
+}
+
+// Uint64SatE casts an interface to a uint64 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func (c *Caster) Uint64SatE(i interface{}) (uint64, bool, error) {
+	n, clamped, err := c.satE(i, "uint64", 64, false)
+	return n.Uint64(), clamped, err
+}
+
+// Uint32SatE casts an interface to a uint32 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func Uint32SatE(i interface{}) (uint32, bool, error) {
+	return std.Uint32SatE(i)
+}
+
+// Uint32SatE casts an interface to a uint32 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func (c *Caster) Uint32SatE(i interface{}) (uint32, bool, error) {
+	n, clamped, err := c.satE(i, "uint32", 32, false)
+	return uint32(n.Uint64()), clamped, err
+}
+
+// Uint16SatE casts an interface to a uint16 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func Uint16SatE(i interface{}) (uint16, bool, error) {
+	return std.Uint16SatE(i)
+}
+
+// Uint16SatE casts an interface to a uint16 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func (c *Caster) Uint16SatE(i interface{}) (uint16, bool, error) {
+	n, clamped, err := c.satE(i, "uint16", 16, false)
+	return uint16(n.Uint64()), clamped, err
+}
+
+// Uint8SatE casts an interface to a uint8 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func Uint8SatE(i interface{}) (uint8, bool, error) {
+	return std.Uint8SatE(i)
+}
+
+// Uint8SatE casts an interface to a uint8 type, clamping numbers beyond
+// its range, negative ones included, to the nearest bound and reporting
+// whether it had to.
+func (c *Caster) Uint8SatE(i interface{}) (uint8, bool, error) {
+	n, clamped, err := c.satE(i, "uint8", 8, false)
+	return uint8(n.Uint64()), clamped, err
+}
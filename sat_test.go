@@ -0,0 +1,80 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSat(t *testing.T) {
+	huge, _ := new(big.Int).SetString("1000000000000000000000", 10)
+
+	tests := []struct {
+		cast    func(i interface{}) (interface{}, bool, error)
+		input   interface{}
+		expect  interface{}
+		clamped bool
+		iserr   bool
+	}{
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, 300, int8(127), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, -300, int8(-128), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, 100, int8(100), false, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, 127.9, int8(127), false, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, "1e6", int8(127), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, "-0x100", int8(-128), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, "1.5", int8(0), false, true},
+		{func(i interface{}) (interface{}, bool, error) { return Int8SatE(i) }, "foo", int8(0), false, true},
+		{func(i interface{}) (interface{}, bool, error) { return Int16SatE(i) }, float32(-1e9), int16(math.MinInt16), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int32SatE(i) }, uint64(math.MaxUint64), int32(math.MaxInt32), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, math.Inf(1), int64(math.MaxInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "99999999999999999999", int64(math.MaxInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, huge, int64(math.MaxInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "1e5000", int64(math.MaxInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "-1e5000", int64(math.MinInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "-2.5E+99999999999999999999", int64(math.MinInt64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "1e-5000", int64(0), false, false},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, "0e5000", int64(0), false, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint8SatE(i) }, "-1e5000", uint8(0), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint8SatE(i) }, "x1e5000", uint8(0), false, true},
+		{func(i interface{}) (interface{}, bool, error) { return Int64SatE(i) }, math.NaN(), int64(0), false, true},
+		{func(i interface{}) (interface{}, bool, error) { return IntSatE(i) }, true, 1, false, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint8SatE(i) }, -5, uint8(0), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint8SatE(i) }, 256.5, uint8(255), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint16SatE(i) }, "70000", uint16(math.MaxUint16), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint32SatE(i) }, math.Inf(-1), uint32(0), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint64SatE(i) }, 1e30, uint64(math.MaxUint64), true, false},
+		{func(i interface{}) (interface{}, bool, error) { return Uint64SatE(i) }, int64(math.MaxInt64), uint64(math.MaxInt64), false, false},
+		{func(i interface{}) (interface{}, bool, error) { return UintSatE(i) }, []int{1}, uint(0), false, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, clamped, err := test.cast(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+		} else {
+			assert.NoError(t, err, errmsg)
+		}
+		assert.Equal(t, test.expect, v, errmsg)
+		assert.Equal(t, test.clamped, clamped, errmsg)
+	}
+
+	_, _, err := Int8SatE(math.NaN())
+	assert.True(t, errors.Is(err, ErrNaN))
+	_, _, err = (&Caster{Exact: true}).Int8SatE(1.5)
+	assert.True(t, errors.Is(err, ErrInexact))
+
+	v, clamped := Int8Sat("300")
+	assert.Equal(t, int8(127), v)
+	assert.True(t, clamped)
+}
@@ -0,0 +1,213 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// siPrefixes maps SI and IEC binary prefixes to the powers they stand for.
+var siPrefixes = map[string]struct{ base, exp int64 }{
+	"a": {10, -18}, "f": {10, -15}, "p": {10, -12}, "n": {10, -9}, "µ": {10, -6}, "μ": {10, -6}, "u": {10, -6}, "m": {10, -3},
+	"k": {10, 3}, "K": {10, 3}, "M": {10, 6}, "G": {10, 9}, "T": {10, 12}, "P": {10, 15}, "E": {10, 18},
+	"Ki": {2, 10}, "Mi": {2, 20}, "Gi": {2, 30}, "Ti": {2, 40}, "Pi": {2, 50}, "Ei": {2, 60},
+}
+
+// siSymbols, binarySymbols and siFractions are the prefixes FormatSIE
+// writes for growing powers of 1000 and 1024, and shrinking ones of 1000.
+var (
+	siSymbols     = []string{"", "k", "M", "G", "T", "P", "E"}
+	binarySymbols = []string{"", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+	siFractions   = []string{"", "m", "µ", "n", "p", "f", "a"}
+)
+
+// parseSI parses s, a decimal number that may end in an SI or IEC binary
+// prefix such as "k" or "Mi", exactly.
+func (c *Caster) parseSI(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(c.ascii(s))
+
+	prefix := ""
+	if strings.HasSuffix(s, "i") && len(s) > 2 {
+		if _, ok := siPrefixes[s[len(s)-2:]]; ok {
+			prefix = s[len(s)-2:]
+		}
+	}
+	if r, n := utf8.DecodeLastRuneInString(s); prefix == "" && n > 0 {
+		if _, ok := siPrefixes[string(r)]; ok {
+			prefix = string(r)
+		}
+	}
+
+	num, err := c.Locale.normalize(strings.TrimSpace(strings.TrimSuffix(s, prefix)))
+	if err != nil {
+		return nil, err
+	}
+	r, err := parseRat(num)
+	if err != nil || prefix == "" {
+		return r, err
+	}
+
+	p := siPrefixes[prefix]
+	if c.SIBinary && p.base == 10 && p.exp > 0 {
+		p.base, p.exp = 2, p.exp/3*10
+	}
+	if p.exp < 0 {
+		m := new(big.Int).Exp(big.NewInt(p.base), big.NewInt(-p.exp), nil)
+		return r.Quo(r, new(big.Rat).SetInt(m)), nil
+	}
+	m := new(big.Int).Exp(big.NewInt(p.base), big.NewInt(p.exp), nil)
+	return r.Mul(r, new(big.Rat).SetInt(m)), nil
+}
+
+// SIInt64E casts an interface to an int64 type, reading strings such as
+// "10k" or "2Mi" that end in an SI or IEC binary prefix.
+func SIInt64E(i interface{}) (int64, error) {
+	return std.SIInt64E(i)
+}
+
+// SIInt64E casts an interface to an int64 type, reading strings such as
+// "10k" or "2Mi" that end in an SI or IEC binary prefix. With c.SIBinary
+// set, the SI prefixes from k up count in powers of 1024 too.
+func (c *Caster) SIInt64E(i interface{}) (int64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return 0, c.nilE("int64")
+	}
+
+	s, ok := i.(string)
+	if !ok {
+		return c.Int64E(i)
+	}
+	r, err := c.parseSI(s)
+	switch {
+	case err != nil:
+		return 0, c.parseE(i, "int64", err)
+	case !r.IsInt():
+		return 0, c.parseE(i, "int64", ErrLostFraction)
+	case !r.Num().IsInt64():
+		return 0, c.parseE(i, "int64", strconv.ErrRange)
+	}
+	return r.Num().Int64(), nil
+}
+
+// SIFloat64E casts an interface to a float64 type, reading strings such as
+// "2.5M" or "300µ" that end in an SI or IEC binary prefix.
+func SIFloat64E(i interface{}) (float64, error) {
+	return std.SIFloat64E(i)
+}
+
+// SIFloat64E casts an interface to a float64 type, reading strings such as
+// "2.5M" or "300µ" that end in an SI or IEC binary prefix. With c.SIBinary
+// set, the SI prefixes from k up count in powers of 1024 too.
+func (c *Caster) SIFloat64E(i interface{}) (float64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return 0, c.nilE("float64")
+	}
+
+	s, ok := i.(string)
+	if !ok {
+		return c.Float64E(i)
+	}
+	r, err := c.parseSI(s)
+	if err != nil {
+		return 0, c.parseE(i, "float64", err)
+	}
+	f, _ := r.Float64()
+	if math.IsInf(f, 0) {
+		return 0, c.parseE(i, "float64", strconv.ErrRange)
+	}
+	return f, nil
+}
+
+// ByteSizeE casts an interface to a uint64 count of bytes, reading strings
+// such as "512", "10KB", "1.5 GiB" or "4M" whose unit may carry an SI or
+// IEC binary prefix.
+func ByteSizeE(i interface{}) (uint64, error) {
+	return std.ByteSizeE(i)
+}
+
+// ByteSizeE casts an interface to a uint64 count of bytes, reading strings
+// such as "512", "10KB", "1.5 GiB" or "4M" whose unit may carry an SI or
+// IEC binary prefix. With c.SIBinary set, "1KB" is 1024 bytes too.
+func (c *Caster) ByteSizeE(i interface{}) (uint64, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return 0, c.nilE("uint64")
+	}
+
+	s, ok := i.(string)
+	if !ok {
+		return c.Uint64E(i)
+	}
+	r, err := c.parseSI(strings.TrimSuffix(strings.TrimSpace(s), "B"))
+	switch {
+	case err != nil:
+		return 0, c.parseE(i, "uint64", err)
+	case r.Sign() < 0:
+		return 0, c.castEf(i, "uint64", "%w", errNegativeNotAllowed)
+	case !r.IsInt():
+		return 0, c.parseE(i, "uint64", ErrLostFraction)
+	case !r.Num().IsUint64():
+		return 0, c.parseE(i, "uint64", strconv.ErrRange)
+	}
+	return r.Num().Uint64(), nil
+}
+
+// FormatSIE casts an interface to a float64 type and writes it compactly
+// with an SI prefix, such as "1.5M" or "250m".
+func FormatSIE(i interface{}) (string, error) {
+	return std.FormatSIE(i)
+}
+
+// FormatSIE casts an interface to a float64 type as SIFloat64E does and
+// writes it compactly with an SI prefix, such as "1.5M" or "250m",
+// keeping up to two fraction digits. Numbers too small for the "a" prefix
+// are written in exponent form. With c.SIBinary set, numbers of at least
+// 1024 are written with an IEC binary prefix such as "1.5Ki".
+func (c *Caster) FormatSIE(i interface{}) (string, error) {
+	v, err := c.SIFloat64E(i)
+	if err != nil {
+		return "", err
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return "", c.castE(i, "SI number")
+	}
+
+	round := func(x float64) float64 { return math.Round(x*100) / 100 }
+	base, symbols := 1000.0, siSymbols
+	if c.SIBinary {
+		base, symbols = 1024, binarySymbols
+	}
+	n := 0
+	switch {
+	case v == 0:
+	case math.Abs(round(v)) >= base:
+		for n+1 < len(symbols) && math.Abs(round(v)) >= base {
+			v /= base
+			n++
+		}
+	case math.Abs(v) < 1:
+		x := v
+		base, symbols = 1000, siFractions
+		for n+1 < len(symbols) && math.Abs(round(v)) < 1 {
+			v *= base
+			n++
+		}
+		if math.Abs(round(v)) < 1 {
+			return strconv.FormatFloat(x, 'g', 3, 64), nil
+		}
+	}
+	s, _ := NumberFormat{MaxFraction: 2}.format(v)
+	return s + symbols[n], nil
+}
@@ -0,0 +1,186 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSIInt64E(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect int64
+		iserr  bool
+	}{
+		{"10k", 10000, false},
+		{"10K", 10000, false},
+		{"1.5M", 1500000, false},
+		{"3G", 3000000000, false},
+		{"2 T", 2000000000000, false},
+		{"-4P", -4000000000000000, false},
+		{"9E", 9000000000000000000, false},
+		{"1Ki", 1024, false},
+		{"1.5Mi", 1572864, false},
+		{"2000m", 2, false},
+		{"42", 42, false},
+		{"1_000k", 1000000, false},
+		{8, 8, false},
+		// errors
+		{"1.5k5", 0, true},
+		{"1.0005k", 0, true},
+		{"10E", 0, true},
+		{"8Ei", 0, true},
+		{"k", 0, true},
+		{"1.5x", 0, true},
+		{"2m", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := SIInt64E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	_, err := SIInt64E("10E")
+	assert.True(t, errors.Is(err, strconv.ErrRange))
+	_, err = SIInt64E("2m")
+	assert.True(t, errors.Is(err, ErrLostFraction))
+
+	v, err := (&Caster{SIBinary: true}).SIInt64E("2k")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2048), v)
+	v, err = (&Caster{SIBinary: true}).SIInt64E("1G")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1<<30), v)
+}
+
+func TestByteSizeE(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect uint64
+		iserr  bool
+	}{
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"10KB", 10000, false},
+		{"10 kB", 10000, false},
+		{"1.5 GiB", 1610612736, false},
+		{"4M", 4000000, false},
+		{uint16(80), 80, false},
+		// errors
+		{"16EiB", 0, true},
+		{"B", 0, true},
+		{"1.5B", 0, true},
+		{"-1KB", 0, true},
+		{"10XB", 0, true},
+		{-1, 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := ByteSizeE(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+
+		// Non-E test
+		v = ByteSize(test.input)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+
+	v, err := (&Caster{SIBinary: true}).ByteSizeE("1KB")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1024), v)
+}
+
+func TestSIFloat64E(t *testing.T) {
+	tests := []struct {
+		input  interface{}
+		expect float64
+		iserr  bool
+	}{
+		{"2.5M", 2.5e6, false},
+		{"300µ", 300e-6, false},
+		{"300μ", 300e-6, false},
+		{"300u", 300e-6, false},
+		{"5n", 5e-9, false},
+		{"250m", 0.25, false},
+		{"0.5Ki", 512, false},
+		{"1e3k", 1e6, false},
+		{1.5, 1.5, false},
+		// errors
+		{"1e999E", 0, true},
+		{"M", 0, true},
+		{"NaN", 0, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := SIFloat64E(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+		assert.NoError(t, err, errmsg)
+		assert.InDelta(t, test.expect, v, 1e-15*test.expect, errmsg)
+	}
+}
+
+func TestFormatSIE(t *testing.T) {
+	tests := []struct {
+		binary bool
+		input  interface{}
+		expect string
+	}{
+		{false, 10000, "10k"},
+		{false, 1500000, "1.5M"},
+		{false, 1536, "1.54k"},
+		{false, 999999, "1M"},
+		{false, -2500, "-2.5k"},
+		{false, 3e21, "3000E"},
+		{false, 42, "42"},
+		{false, 0, "0"},
+		{false, 0.25, "250m"},
+		{false, 0.0000025, "2.5µ"},
+		{false, 5e-9, "5n"},
+		{false, 1e-12, "1p"},
+		{false, 1.5e-15, "1.5f"},
+		{false, -2e-18, "-2a"},
+		{false, 1e-21, "1e-21"},
+		{false, "-3.25e-20", "-3.25e-20"},
+		{false, "7p", "7p"},
+		{false, "2.5M", "2.5M"},
+		{true, 1024, "1Ki"},
+		{true, 1572864, "1.5Mi"},
+		{true, 1000, "1000"},
+		{true, 0.5, "500m"},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := (&Caster{SIBinary: test.binary}).FormatSIE(test.input)
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"reflect"
+	"time"
+)
+
+// Int64SliceE casts an interface to a []int64 type.
+func Int64SliceE(i interface{}) ([]int64, error) {
+	return std.Int64SliceE(i)
+}
+
+// Int64SliceE casts an interface to a []int64 type.
+func (c *Caster) Int64SliceE(i interface{}) ([]int64, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]int64(nil)), func(e interface{}) (interface{}, error) {
+		return c.Int64E(e)
+	})
+	return v.([]int64), err
+}
+
+// Int32SliceE casts an interface to a []int32 type.
+func Int32SliceE(i interface{}) ([]int32, error) {
+	return std.Int32SliceE(i)
+}
+
+// Int32SliceE casts an interface to a []int32 type.
+func (c *Caster) Int32SliceE(i interface{}) ([]int32, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]int32(nil)), func(e interface{}) (interface{}, error) {
+		return c.Int32E(e)
+	})
+	return v.([]int32), err
+}
+
+// Int16SliceE casts an interface to a []int16 type.
+func Int16SliceE(i interface{}) ([]int16, error) {
+	return std.Int16SliceE(i)
+}
+
+// Int16SliceE casts an interface to a []int16 type.
+func (c *Caster) Int16SliceE(i interface{}) ([]int16, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]int16(nil)), func(e interface{}) (interface{}, error) {
+		return c.Int16E(e)
+	})
+	return v.([]int16), err
+}
+
+// Int8SliceE casts an interface to a []int8 type.
+func Int8SliceE(i interface{}) ([]int8, error) {
+	return std.Int8SliceE(i)
+}
+
+// Int8SliceE casts an interface to a []int8 type.
+func (c *Caster) Int8SliceE(i interface{}) ([]int8, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]int8(nil)), func(e interface{}) (interface{}, error) {
+		return c.Int8E(e)
+	})
+	return v.([]int8), err
+}
+
+// UintSliceE casts an interface to a []uint type.
+func UintSliceE(i interface{}) ([]uint, error) {
+	return std.UintSliceE(i)
+}
+
+// UintSliceE casts an interface to a []uint type.
+func (c *Caster) UintSliceE(i interface{}) ([]uint, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint(nil)), func(e interface{}) (interface{}, error) {
+		return c.UintE(e)
+	})
+	return v.([]uint), err
+}
+
+// Uint64SliceE casts an interface to a []uint64 type.
+func Uint64SliceE(i interface{}) ([]uint64, error) {
+	return std.Uint64SliceE(i)
+}
+
+// Uint64SliceE casts an interface to a []uint64 type.
+func (c *Caster) Uint64SliceE(i interface{}) ([]uint64, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint64(nil)), func(e interface{}) (interface{}, error) {
+		return c.Uint64E(e)
+	})
+	return v.([]uint64), err
+}
+
+// Uint32SliceE casts an interface to a []uint32 type.
+func Uint32SliceE(i interface{}) ([]uint32, error) {
+	return std.Uint32SliceE(i)
+}
+
+// Uint32SliceE casts an interface to a []uint32 type.
+func (c *Caster) Uint32SliceE(i interface{}) ([]uint32, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint32(nil)), func(e interface{}) (interface{}, error) {
+		return c.Uint32E(e)
+	})
+	return v.([]uint32), err
+}
+
+// Uint16SliceE casts an interface to a []uint16 type.
+func Uint16SliceE(i interface{}) ([]uint16, error) {
+	return std.Uint16SliceE(i)
+}
+
+// Uint16SliceE casts an interface to a []uint16 type.
+func (c *Caster) Uint16SliceE(i interface{}) ([]uint16, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint16(nil)), func(e interface{}) (interface{}, error) {
+		return c.Uint16E(e)
+	})
+	return v.([]uint16), err
+}
+
+// Uint8SliceE casts an interface to a []uint8 type.
+func Uint8SliceE(i interface{}) ([]uint8, error) {
+	return std.Uint8SliceE(i)
+}
+
+// Uint8SliceE casts an interface to a []uint8 type.
+func (c *Caster) Uint8SliceE(i interface{}) ([]uint8, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint8(nil)), func(e interface{}) (interface{}, error) {
+		return c.Uint8E(e)
+	})
+	return v.([]uint8), err
+}
+
+// Float64SliceE casts an interface to a []float64 type.
+func Float64SliceE(i interface{}) ([]float64, error) {
+	return std.Float64SliceE(i)
+}
+
+// Float64SliceE casts an interface to a []float64 type.
+func (c *Caster) Float64SliceE(i interface{}) ([]float64, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]float64(nil)), func(e interface{}) (interface{}, error) {
+		return c.Float64E(e)
+	})
+	return v.([]float64), err
+}
+
+// Float32SliceE casts an interface to a []float32 type.
+func Float32SliceE(i interface{}) ([]float32, error) {
+	return std.Float32SliceE(i)
+}
+
+// Float32SliceE casts an interface to a []float32 type.
+func (c *Caster) Float32SliceE(i interface{}) ([]float32, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]float32(nil)), func(e interface{}) (interface{}, error) {
+		return c.Float32E(e)
+	})
+	return v.([]float32), err
+}
+
+// TimeSliceE casts an interface to a []time.Time type.
+func TimeSliceE(i interface{}) ([]time.Time, error) {
+	return std.TimeSliceE(i)
+}
+
+// TimeSliceE casts an interface to a []time.Time type.
+func (c *Caster) TimeSliceE(i interface{}) ([]time.Time, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]time.Time(nil)), func(e interface{}) (interface{}, error) {
+		return c.TimeE(e)
+	})
+	return v.([]time.Time), err
+}
+
+// ByteSizeSliceE casts an interface to a []uint64 type of byte counts,
+// such as "10KB, 1.5GiB", reading each element as ByteSizeE does.
+func ByteSizeSliceE(i interface{}) ([]uint64, error) {
+	return std.ByteSizeSliceE(i)
+}
+
+// ByteSizeSliceE casts an interface to a []uint64 type of byte counts,
+// such as "10KB, 1.5GiB", reading each element as ByteSizeE does.
+func (c *Caster) ByteSizeSliceE(i interface{}) ([]uint64, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]uint64(nil)), func(e interface{}) (interface{}, error) {
+		return c.ByteSizeE(e)
+	})
+	return v.([]uint64), err
+}
+
+// sliceE casts i, a slice or an array, a pointer to one, or a string
+// split by splitList, to a slice of type typ by casting each of its elements
+// with cast.
+func (c *Caster) sliceE(i interface{}, typ reflect.Type, cast func(interface{}) (interface{}, error)) (interface{}, error) {
+	i = indirect(i)
+
+	if isNil(i) {
+		return reflect.Zero(typ).Interface(), c.nilE(typ.String())
+	}
+	if reflect.TypeOf(i) == typ {
+		return i, nil
+	}
+
+	v := reflect.ValueOf(i)
+	if s, ok := i.(string); ok {
+		a, err := c.splitList(s)
+		if err != nil {
+			return reflect.MakeSlice(typ, 0, 0).Interface(), c.castEf(i, typ.String(), "%w", err)
+		}
+		v = reflect.ValueOf(a)
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		a := reflect.MakeSlice(typ, v.Len(), v.Len())
+		errs := c.elementErrors(typ.String())
+		for j := 0; j < v.Len(); j++ {
+			val, err := cast(v.Index(j).Interface())
+			if err != nil {
+				if errs.index(j, err) {
+					break
+				}
+				continue
+			}
+			if val != nil {
+				a.Index(j).Set(reflect.ValueOf(val))
+			}
+		}
+		if err := errs.err(); err != nil {
+			return reflect.MakeSlice(typ, 0, 0).Interface(), err
+		}
+		return a.Interface(), nil
+	default:
+		return reflect.MakeSlice(typ, 0, 0).Interface(), c.castE(i, typ.String())
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedSliceE(t *testing.T) {
+	ints := []int64{1, 2, 3}
+	var nilSlice *[]int64
+
+	tests := []struct {
+		cast   func(interface{}) (interface{}, error)
+		input  interface{}
+		expect interface{}
+		iserr  bool
+	}{
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, []interface{}{1, "2", 3.0}, ints, false},
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, &ints, ints, false},
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, [3]uint8{1, 2, 3}, ints, false},
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, "1 2 3", ints, false},
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, nilSlice, []int64(nil), false},
+		{func(i interface{}) (interface{}, error) { return Int32SliceE(i) }, []string{"1", "2"}, []int32{1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return Int16SliceE(i) }, []int{1, 2}, []int16{1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return Int8SliceE(i) }, []int{-1, 2}, []int8{-1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return UintSliceE(i) }, []int{1, 2}, []uint{1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return Uint64SliceE(i) }, "7 8", []uint64{7, 8}, false},
+		{func(i interface{}) (interface{}, error) { return Uint32SliceE(i) }, []float64{1, 2}, []uint32{1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return Uint16SliceE(i) }, []string{"80", "443"}, []uint16{80, 443}, false},
+		{func(i interface{}) (interface{}, error) { return Uint8SliceE(i) }, []byte("ab"), []uint8{'a', 'b'}, false},
+		{func(i interface{}) (interface{}, error) { return Float64SliceE(i) }, "1.5 2", []float64{1.5, 2}, false},
+		{func(i interface{}) (interface{}, error) { return Float32SliceE(i) }, []int{1, 2}, []float32{1, 2}, false},
+		{func(i interface{}) (interface{}, error) { return TimeSliceE(i) }, []interface{}{"2006-01-02", 0}, []time.Time{time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC), time.Unix(0, 0)}, false},
+		{func(i interface{}) (interface{}, error) { return IntSliceE(i) }, "1 2 3", []int{1, 2, 3}, false},
+		{func(i interface{}) (interface{}, error) { return BoolSliceE(i) }, "yes no", []bool{true, false}, false},
+		{func(i interface{}) (interface{}, error) { return DurationSliceE(i) }, "1s 2m", []time.Duration{time.Second, 2 * time.Minute}, false},
+		{func(i interface{}) (interface{}, error) { return ByteSizeSliceE(i) }, "10KB, 1.5GiB, 512", []uint64{10000, 1610612736, 512}, false},
+		{func(i interface{}) (interface{}, error) { return ByteSizeSliceE(i) }, []interface{}{"2Ki", 3}, []uint64{2048, 3}, false},
+		// errors
+		{func(i interface{}) (interface{}, error) { return Int64SliceE(i) }, []string{"1", "x"}, nil, true},
+		{func(i interface{}) (interface{}, error) { return ByteSizeSliceE(i) }, "1KB 1.5B", nil, true},
+		{func(i interface{}) (interface{}, error) { return Uint8SliceE(i) }, []int{-1}, nil, true},
+		{func(i interface{}) (interface{}, error) { return Float64SliceE(i) }, testing.T{}, nil, true},
+		{func(i interface{}) (interface{}, error) { return TimeSliceE(i) }, "never", nil, true},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		v, err := test.cast(test.input)
+		if test.iserr {
+			assert.Error(t, err, errmsg)
+			continue
+		}
+
+		assert.NoError(t, err, errmsg)
+		assert.Equal(t, test.expect, v, errmsg)
+	}
+}
+
+func TestTypedSlice(t *testing.T) {
+	assert.Equal(t, []int64{1, 2}, Int64Slice("1 2"))
+	assert.Equal(t, []int32{1, 2}, Int32Slice("1 2"))
+	assert.Equal(t, []int16{1, 2}, Int16Slice("1 2"))
+	assert.Equal(t, []int8{1, 2}, Int8Slice("1 2"))
+	assert.Equal(t, []uint{1, 2}, UintSlice("1 2"))
+	assert.Equal(t, []uint64{1, 2}, Uint64Slice("1 2"))
+	assert.Equal(t, []uint32{1, 2}, Uint32Slice("1 2"))
+	assert.Equal(t, []uint16{1, 2}, Uint16Slice("1 2"))
+	assert.Equal(t, []uint8{1, 2}, Uint8Slice("1 2"))
+	assert.Equal(t, []float64{1, 2}, Float64Slice("1 2"))
+	assert.Equal(t, []float32{1, 2}, Float32Slice("1 2"))
+	assert.Equal(t, []time.Time{}, TimeSlice("x"))
+}
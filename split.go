@@ -0,0 +1,142 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// defaultSeparators split slice elements on commas and white space.
+const defaultSeparators = ", "
+
+// splitList splits s into slice elements. A string holding a JSON array is
+// decoded, with numbers kept as their text; anything else, bracketed text
+// such as "[WARN]" that is not JSON included, is split on c.Separators.
+func (c *Caster) splitList(s string) ([]interface{}, error) {
+	if t := strings.TrimSpace(s); strings.HasPrefix(t, "[") && strings.HasSuffix(t, "]") {
+		if err := c.checkJSON(t); err != nil {
+			return nil, err
+		}
+		var a []interface{}
+		d := json.NewDecoder(strings.NewReader(t))
+		d.UseNumber()
+		if err := d.Decode(&a); err == nil && d.InputOffset() == int64(len(t)) {
+			for j, e := range a {
+				if n, ok := e.(json.Number); ok {
+					a[j] = string(n)
+				}
+			}
+			return a, nil
+		}
+	}
+
+	if err := c.checkLen(s); err != nil {
+		return nil, err
+	}
+	fields := splitFields(s, c.separators())
+	if err := c.checkElements(len(fields)); err != nil {
+		return nil, err
+	}
+	a := make([]interface{}, len(fields))
+	for j, f := range fields {
+		a[j] = f
+	}
+	return a, nil
+}
+
+func (c *Caster) separators() string {
+	if c.Separators == "" {
+		return defaultSeparators
+	}
+	return c.Separators
+}
+
+// splitFields splits s on any rune of seps, where white space stands for
+// any run of white space. Elements are trimmed of white space and may be
+// quoted with " or ' at their start, doubling the quote or preceding it
+// with a backslash to include it. Outside quotes a backslash makes a
+// following quote, separator, white space or backslash literal.
+func splitFields(s string, seps string) []string {
+	fields, _ := scanFields(s, seps)
+	return fields
+}
+
+// scanFields is splitFields also returning the separator that ended each
+// field, a space for white space and 0 for the end of s.
+func scanFields(s string, seps string) (fields []string, ends []rune) {
+	soft := strings.IndexFunc(seps, unicode.IsSpace) >= 0
+	special := func(r rune) bool {
+		return r == '"' || r == '\'' || r == '\\' || unicode.IsSpace(r) || strings.ContainsRune(seps, r)
+	}
+
+	var (
+		field   bytes.Buffer
+		space   bytes.Buffer // white space not yet known to be inside a field
+		content bool         // whether field has been started
+		open    bool         // whether a separator opened a field that must be emitted
+		quote   rune
+	)
+	emit := func(end rune) {
+		fields = append(fields, field.String())
+		ends = append(ends, end)
+		field.Reset()
+		space.Reset()
+		content, open = false, false
+	}
+	start := func() {
+		if space.Len() > 0 && content {
+			if soft {
+				emit(' ')
+			} else {
+				field.Write(space.Bytes())
+			}
+		}
+		space.Reset()
+		content = true
+	}
+
+	rs := []rune(s)
+	for j := 0; j < len(rs); j++ {
+		r := rs[j]
+		switch {
+		case quote != 0:
+			switch {
+			case r == '\\' && j+1 < len(rs) && (rs[j+1] == quote || rs[j+1] == '\\'):
+				j++
+				field.WriteRune(rs[j])
+			case r == quote && j+1 < len(rs) && rs[j+1] == quote:
+				j++
+				field.WriteRune(r)
+			case r == quote:
+				quote = 0
+			default:
+				field.WriteRune(r)
+			}
+		case r == '\\' && j+1 < len(rs) && special(rs[j+1]):
+			j++
+			start()
+			field.WriteRune(rs[j])
+		case (r == '"' || r == '\'') && (!content || soft && space.Len() > 0):
+			start()
+			quote = r
+		case unicode.IsSpace(r):
+			space.WriteRune(r)
+		case strings.ContainsRune(seps, r):
+			emit(r)
+			open = true
+		default:
+			start()
+			field.WriteRune(r)
+		}
+	}
+	if content || open {
+		emit(0)
+	}
+	return fields, ends
+}
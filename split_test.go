@@ -0,0 +1,91 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitFields(t *testing.T) {
+	tests := []struct {
+		input  string
+		seps   string
+		expect []string
+	}{
+		{"", ", ", nil},
+		{"a b  c", ", ", []string{"a", "b", "c"}},
+		{"1,2,3", ", ", []string{"1", "2", "3"}},
+		{" 1 , 2 ,3 ", ", ", []string{"1", "2", "3"}},
+		{"a,,b,", ",", []string{"a", "", "b", ""}},
+		{"New York; Boston", ";", []string{"New York", "Boston"}},
+		{"a|b | c", "|", []string{"a", "b", "c"}},
+		{`"New York", Boston`, ", ", []string{"New York", "Boston"}},
+		{`'a, b' "c ""d"" e" 'it\'s'`, ", ", []string{"a, b", `c "d" e`, "it's"}},
+		{`it's,fine`, ",", []string{"it's", "fine"}},
+		{`a\,b,c\ d,e\\f,g\h`, ", ", []string{"a,b", "c d", `e\f`, `g\h`}},
+		{`" padded ",x`, ",", []string{" padded ", "x"}},
+		{`"",x`, ",", []string{"", "x"}},
+	}
+
+	for i, test := range tests {
+		errmsg := fmt.Sprintf("i = %d", i) // assert helper message
+
+		assert.Equal(t, test.expect, splitFields(test.input, test.seps), errmsg)
+	}
+}
+
+func TestDelimitedSliceE(t *testing.T) {
+	v, err := IntSliceE("1,2,3")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, v)
+
+	v, err = IntSliceE(" [1, 2, 3] ")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, v)
+
+	i64, err := Int64SliceE("[9007199254740993]")
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{9007199254740993}, i64)
+
+	d, err := DurationSliceE("1s,2s")
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, d)
+
+	s, err := StringSliceE(`a, "b, c"`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b, c"}, s)
+
+	s, err = StringSliceE(`["a", 1, true]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "1", "true"}, s)
+
+	l, err := SliceE("a,b")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, l)
+
+	c := &Caster{Separators: ";"}
+	s, err = c.StringSliceE("New York; Boston")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"New York", "Boston"}, s)
+
+	_, err = IntSliceE("[1, 2")
+	assert.Error(t, err)
+	s, err = StringSliceE("[WARN]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[WARN]"}, s)
+	s, err = StringSliceE("[WARN] [ERROR]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[WARN]", "[ERROR]"}, s)
+	s, err = StringSliceE("[1, 2,]")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"[1", "2", "]"}, s)
+	_, err = IntSliceE("1,,2")
+	assert.Error(t, err)
+}
@@ -7,7 +7,10 @@
 
 package to
 
-import "time"
+import (
+	"math/big"
+	"time"
+)
 
 // Bool casts an interface to a bool type.
 func Bool(i interface{}) bool {
@@ -170,3 +173,302 @@ func DurationSlice(i interface{}) []time.Duration {
 	v, _ := DurationSliceE(i)
 	return v
 }
+
+// EpochTime casts a number to a time.Time counted in the units of e.
+func EpochTime(i interface{}, e Epoch) time.Time {
+	v, _ := EpochTimeE(i, e)
+	return v
+}
+
+// EpochValue casts an interface to a number counted in the units of e.
+func EpochValue(i interface{}, e Epoch) float64 {
+	v, _ := EpochValueE(i, e)
+	return v
+}
+
+// Truthy reports whether an interface is truthy under the text/template
+// rules.
+func Truthy(i interface{}) bool {
+	v, _ := TruthyE(i)
+	return v
+}
+
+// Int64Slice casts an interface to a []int64 type.
+func Int64Slice(i interface{}) []int64 {
+	v, _ := Int64SliceE(i)
+	return v
+}
+
+// Int32Slice casts an interface to a []int32 type.
+func Int32Slice(i interface{}) []int32 {
+	v, _ := Int32SliceE(i)
+	return v
+}
+
+// Int16Slice casts an interface to a []int16 type.
+func Int16Slice(i interface{}) []int16 {
+	v, _ := Int16SliceE(i)
+	return v
+}
+
+// Int8Slice casts an interface to a []int8 type.
+func Int8Slice(i interface{}) []int8 {
+	v, _ := Int8SliceE(i)
+	return v
+}
+
+// UintSlice casts an interface to a []uint type.
+func UintSlice(i interface{}) []uint {
+	v, _ := UintSliceE(i)
+	return v
+}
+
+// Uint64Slice casts an interface to a []uint64 type.
+func Uint64Slice(i interface{}) []uint64 {
+	v, _ := Uint64SliceE(i)
+	return v
+}
+
+// Uint32Slice casts an interface to a []uint32 type.
+func Uint32Slice(i interface{}) []uint32 {
+	v, _ := Uint32SliceE(i)
+	return v
+}
+
+// Uint16Slice casts an interface to a []uint16 type.
+func Uint16Slice(i interface{}) []uint16 {
+	v, _ := Uint16SliceE(i)
+	return v
+}
+
+// Uint8Slice casts an interface to a []uint8 type.
+func Uint8Slice(i interface{}) []uint8 {
+	v, _ := Uint8SliceE(i)
+	return v
+}
+
+// Float64Slice casts an interface to a []float64 type.
+func Float64Slice(i interface{}) []float64 {
+	v, _ := Float64SliceE(i)
+	return v
+}
+
+// Float32Slice casts an interface to a []float32 type.
+func Float32Slice(i interface{}) []float32 {
+	v, _ := Float32SliceE(i)
+	return v
+}
+
+// TimeSlice casts an interface to a []time.Time type.
+func TimeSlice(i interface{}) []time.Time {
+	v, _ := TimeSliceE(i)
+	return v
+}
+
+// ByteSizeSlice casts an interface to a []uint64 type of byte counts, such
+// as "10KB, 1.5GiB".
+func ByteSizeSlice(i interface{}) []uint64 {
+	v, _ := ByteSizeSliceE(i)
+	return v
+}
+
+// IntRange casts a range list such as "8000-8010,9000" to a []int type.
+func IntRange(i interface{}) []int {
+	v, _ := IntRangeE(i)
+	return v
+}
+
+// Int64Range casts a range list such as "8000-8010,9000" to a []int64 type.
+func Int64Range(i interface{}) []int64 {
+	v, _ := Int64RangeE(i)
+	return v
+}
+
+// Uint16Range casts a range list such as "8000-8010,9000" to a []uint16
+// type.
+func Uint16Range(i interface{}) []uint16 {
+	v, _ := Uint16RangeE(i)
+	return v
+}
+
+// FormatRange compresses an interface cast to a []int64 type into range
+// list notation.
+func FormatRange(i interface{}) string {
+	v, _ := FormatRangeE(i)
+	return v
+}
+
+// Normalize casts an interface to a tree of map[string]interface{} and
+// []interface{} values that encoding/json accepts.
+func Normalize(i interface{}) interface{} {
+	v, _ := NormalizeE(i)
+	return v
+}
+
+// Float64Locale casts an interface to a float64 type, reading strings in l.
+func Float64Locale(i interface{}, l Locale) float64 {
+	v, _ := Float64LocaleE(i, l)
+	return v
+}
+
+// Int64Locale casts an interface to an int64 type, reading strings in l.
+func Int64Locale(i interface{}, l Locale) int64 {
+	v, _ := Int64LocaleE(i, l)
+	return v
+}
+
+// FormatNumber casts an interface to a number and writes it in f.
+func FormatNumber(i interface{}, f NumberFormat) string {
+	v, _ := FormatNumberE(i, f)
+	return v
+}
+
+// SIInt64 casts an interface to an int64 type, reading strings such as
+// "10k" or "2Mi" that end in an SI or IEC binary prefix.
+func SIInt64(i interface{}) int64 {
+	v, _ := SIInt64E(i)
+	return v
+}
+
+// SIFloat64 casts an interface to a float64 type, reading strings such as
+// "2.5M" or "300µ" that end in an SI or IEC binary prefix.
+func SIFloat64(i interface{}) float64 {
+	v, _ := SIFloat64E(i)
+	return v
+}
+
+// ByteSize casts an interface to a uint64 count of bytes, reading strings
+// such as "10KB" or "1.5 GiB".
+func ByteSize(i interface{}) uint64 {
+	v, _ := ByteSizeE(i)
+	return v
+}
+
+// FormatSI casts an interface to a float64 type and writes it compactly
+// with an SI prefix, such as "1.5M" or "250m".
+func FormatSI(i interface{}) string {
+	v, _ := FormatSIE(i)
+	return v
+}
+
+// Rat casts an interface to a *big.Rat type, reading fractions such as
+// "3/4", ratios such as "1:3" and percentages such as "45%".
+func Rat(i interface{}) *big.Rat {
+	v, _ := RatE(i)
+	return v
+}
+
+// Ratio casts an interface to a float64 type, so that "45%" and "9/20"
+// are both 0.45.
+func Ratio(i interface{}) float64 {
+	v, _ := RatioE(i)
+	return v
+}
+
+// RatioIn casts an interface to a float64 type as Ratio does, giving zero
+// when it does not lie between min and max.
+func RatioIn(i interface{}, min, max float64) float64 {
+	v, _ := RatioInE(i, min, max)
+	return v
+}
+
+// Percent casts an interface to a float64 type counted in percent.
+func Percent(i interface{}) float64 {
+	v, _ := PercentE(i)
+	return v
+}
+
+// FormatPercent casts an interface to a ratio and writes it as a
+// percentage with at most digits fraction digits.
+func FormatPercent(i interface{}, digits int) string {
+	v, _ := FormatPercentE(i, digits)
+	return v
+}
+
+// Int64Base casts an interface to an int64 type, reading strings in base.
+func Int64Base(i interface{}, base int) int64 {
+	v, _ := Int64BaseE(i, base)
+	return v
+}
+
+// Uint64Base casts an interface to a uint64 type, reading strings in base.
+func Uint64Base(i interface{}, base int) uint64 {
+	v, _ := Uint64BaseE(i, base)
+	return v
+}
+
+// FormatInt casts an interface to an integer and writes it in f.
+func FormatInt(i interface{}, f IntFormat) string {
+	v, _ := FormatIntE(i, f)
+	return v
+}
+
+// Int64Sat casts an interface to an int64 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Int64Sat(i interface{}) (int64, bool) {
+	v, clamped, _ := Int64SatE(i)
+	return v, clamped
+}
+
+// Int32Sat casts an interface to an int32 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Int32Sat(i interface{}) (int32, bool) {
+	v, clamped, _ := Int32SatE(i)
+	return v, clamped
+}
+
+// Int16Sat casts an interface to an int16 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Int16Sat(i interface{}) (int16, bool) {
+	v, clamped, _ := Int16SatE(i)
+	return v, clamped
+}
+
+// Int8Sat casts an interface to an int8 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Int8Sat(i interface{}) (int8, bool) {
+	v, clamped, _ := Int8SatE(i)
+	return v, clamped
+}
+
+// IntSat casts an interface to an int type, clamping numbers beyond its
+// range and reporting whether it had to.
+func IntSat(i interface{}) (int, bool) {
+	v, clamped, _ := IntSatE(i)
+	return v, clamped
+}
+
+// UintSat casts an interface to a uint type, clamping numbers beyond its
+// range and reporting whether it had to.
+func UintSat(i interface{}) (uint, bool) {
+	v, clamped, _ := UintSatE(i)
+	return v, clamped
+}
+
+// Uint64Sat casts an interface to a uint64 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Uint64Sat(i interface{}) (uint64, bool) {
+	v, clamped, _ := Uint64SatE(i)
+	return v, clamped
+}
+
+// Uint32Sat casts an interface to a uint32 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Uint32Sat(i interface{}) (uint32, bool) {
+	v, clamped, _ := Uint32SatE(i)
+	return v, clamped
+}
+
+// Uint16Sat casts an interface to a uint16 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Uint16Sat(i interface{}) (uint16, bool) {
+	v, clamped, _ := Uint16SatE(i)
+	return v, clamped
+}
+
+// Uint8Sat casts an interface to a uint8 type, clamping numbers beyond its
+// range and reporting whether it had to.
+func Uint8Sat(i interface{}) (uint8, bool) {
+	v, clamped, _ := Uint8SatE(i)
+	return v, clamped
+}
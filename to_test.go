@@ -6,6 +6,7 @@
 package to
 
 import (
+	"encoding/json"
 	"fmt"
 	"html/template"
 	"testing"
@@ -724,8 +725,8 @@ func TestStringMapStringSliceE(t *testing.T) {
 		{interfaceMapInterface, stringMapStringSingleSliceFieldsResult, false},
 		{jsonStringMapStringArray, jsonStringMapStringArrayResult, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{map[interface{}]interface{}{"foo": testing.T{}}, nil, true},
 		{map[interface{}]interface{}{Key{"foo"}: "bar"}, nil, true}, // ToStringE(Key{"foo"}) should fail
@@ -762,8 +763,8 @@ func TestStringMapE(t *testing.T) {
 		{`{"tag": "tags", "group": "groups"}`, map[string]interface{}{"tag": "tags", "group": "groups"}, false},
 		{`{"tag": "tags", "group": true}`, map[string]interface{}{"tag": "tags", "group": true}, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{"", nil, true},
 	}
@@ -797,8 +798,8 @@ func TestStringMapBoolE(t *testing.T) {
 		{map[string]bool{"v1": true, "v2": false}, map[string]bool{"v1": true, "v2": false}, false},
 		{`{"v1": true, "v2": false}`, map[string]bool{"v1": true, "v2": false}, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{"", nil, true},
 	}
@@ -835,8 +836,8 @@ func TestStringMapIntE(t *testing.T) {
 		{map[string]float64{"v1": float64(8.22), "v2": float64(43.32)}, map[string]int{"v1": 8, "v2": 43}, false},
 		{`{"v1": 67, "v2": 56}`, map[string]int{"v1": 67, "v2": 56}, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{"", nil, true},
 	}
@@ -874,8 +875,8 @@ func TestStringMapInt64E(t *testing.T) {
 		{map[string]float64{"v1": float64(8.22), "v2": float64(43.32)}, map[string]int64{"v1": 8, "v2": 43}, false},
 		{`{"v1": 67, "v2": 56}`, map[string]int64{"v1": 67, "v2": 56}, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{"", nil, true},
 	}
@@ -918,8 +919,8 @@ func TestStringMapStringE(t *testing.T) {
 		{interfaceMapInterface, stringMapString, false},
 		{jsonString, stringMapString, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{invalidJsonString, nil, true},
 		{emptyString, nil, true},
@@ -953,8 +954,8 @@ func TestBoolSliceE(t *testing.T) {
 		{[]interface{}{true, false, true}, []bool{true, false, true}, false},
 		{[]int{1, 0, 1}, []bool{true, false, true}, false},
 		{[]string{"true", "false", "true"}, []bool{true, false, true}, false},
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"foo", "bar"}, nil, true},
 	}
@@ -987,8 +988,8 @@ func TestIntSliceE(t *testing.T) {
 		{[]interface{}{1.2, 3.2}, []int{1, 3}, false},
 		{[]string{"2", "3"}, []int{2, 3}, false},
 		{[2]string{"2", "3"}, []int{2, 3}, false},
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"foo", "bar"}, nil, true},
 	}
@@ -1019,8 +1020,8 @@ func TestSliceE(t *testing.T) {
 	}{
 		{[]interface{}{1, 3}, []interface{}{1, 3}, false},
 		{[]map[string]interface{}{{"k1": 1}, {"k2": 2}}, []interface{}{map[string]interface{}{"k1": 1}, map[string]interface{}{"k2": 2}}, false},
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 	}
 
@@ -1051,8 +1052,8 @@ func TestStringSliceE(t *testing.T) {
 		{[]string{"a", "b"}, []string{"a", "b"}, false},
 		{[]interface{}{1, 3}, []string{"1", "3"}, false},
 		{interface{}(1), []string{"1"}, false},
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 	}
 
@@ -1085,8 +1086,8 @@ func TestDurationSliceE(t *testing.T) {
 		{[]interface{}{1, 3}, []time.Duration{1, 3}, false},
 		{[]time.Duration{1, 3}, []time.Duration{1, 3}, false},
 
+		{nil, nil, false},
 		// errors
-		{nil, nil, true},
 		{testing.T{}, nil, true},
 		{[]string{"invalid"}, nil, true},
 	}
@@ -1133,8 +1134,27 @@ func TestBoolE(t *testing.T) {
 		{true, true, false},
 		{-1, true, false},
 
+		{" yes ", true, false},
+		{"On", true, false},
+		{"y", true, false},
+		{"enabled", true, false},
+		{"NO", false, false},
+		{"off", false, false},
+		{"n", false, false},
+		{"disabled", false, false},
+		{int64(1), true, false},
+		{uint8(0), false, false},
+		{float64(1.0), true, false},
+		{float32(0), false, false},
+		{json.Number("1"), true, false},
+		{json.Number("0.0"), false, false},
+		{time.Second, true, false},
+
 		// errors
 		{"test", false, true},
+		{"", false, true},
+		{"да", false, true},
+		{json.Number("x"), false, true},
 		{testing.T{}, false, true},
 	}
 
@@ -1156,6 +1176,26 @@ func TestBoolE(t *testing.T) {
 	}
 }
 
+func TestCasterBoolWords(t *testing.T) {
+	c := &Caster{BoolWords: map[string]bool{"да": true, "нет": false, "yes": false}}
+
+	v, err := c.BoolE(" ДА ")
+	assert.NoError(t, err)
+	assert.True(t, v)
+
+	v, err = c.BoolE("Нет")
+	assert.NoError(t, err)
+	assert.False(t, v)
+
+	v, err = c.BoolE("yes")
+	assert.NoError(t, err)
+	assert.False(t, v)
+
+	v, err = c.BoolE("on")
+	assert.NoError(t, err)
+	assert.True(t, v)
+}
+
 func BenchmarkBool(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		if !Bool(true) {
@@ -18,15 +18,42 @@ import (
 
 var errNegativeNotAllowed = errors.New("unable to cast negative value")
 
+// boolWords are the strings BoolE understands, keyed in lower case.
+var boolWords = map[string]bool{
+	"1": true, "t": true, "true": true, "y": true, "yes": true, "on": true, "enabled": true,
+	"0": false, "f": false, "false": false, "n": false, "no": false, "off": false, "disabled": false,
+}
+
 // TimeE casts an interface to a time.Time type.
 func TimeE(i interface{}) (tim time.Time, err error) {
+	return std.TimeE(i)
+}
+
+// TimeE casts an interface to a time.Time type, reading numbers in the
+// units of c.TimeEpoch.
+func (c *Caster) TimeE(i interface{}) (tim time.Time, err error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return time.Time{}, c.nilE("Time")
+	}
+
+	if c.TimeEpoch != EpochUnix {
+		switch i.(type) {
+		case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8, float64, float32:
+			return c.EpochTimeE(i, c.TimeEpoch)
+		}
+	}
+
 	switch v := i.(type) {
 	case time.Time:
 		return v, nil
 	case string:
-		return StringToDate(v)
+		t, err := StringToDate(c.ascii(v))
+		if err != nil {
+			return time.Time{}, c.castE(i, "Time")
+		}
+		return t, nil
 	case int:
 		return time.Unix(int64(v), 0), nil
 	case int64:
@@ -40,70 +67,145 @@ func TimeE(i interface{}) (tim time.Time, err error) {
 	case uint32:
 		return time.Unix(int64(v), 0), nil
 	default:
-		return time.Time{}, fmt.Errorf("unable to cast %#v of type %T to Time", i, i)
+		return time.Time{}, c.castE(i, "Time")
 	}
 }
 
 // DurationE casts an interface to a time.Duration type.
 func DurationE(i interface{}) (d time.Duration, err error) {
+	return std.DurationE(i)
+}
+
+// DurationE casts an interface to a time.Duration type.
+func (c *Caster) DurationE(i interface{}) (d time.Duration, err error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("Duration")
+	}
+
 	switch s := i.(type) {
 	case time.Duration:
 		return s, nil
 	case int, int64, int32, int16, int8, uint, uint64, uint32, uint16, uint8:
-		d = time.Duration(Int64(s))
-		return
-	case float32, float64:
-		d = time.Duration(Float64(s))
+		n, _ := c.Int64E(s)
+		d = time.Duration(n)
 		return
+	case float64:
+		f, err := c.floatInt(i, s, "Duration", 64, true)
+		return time.Duration(f), err
+	case float32:
+		f, err := c.floatInt(i, float64(s), "Duration", 64, true)
+		return time.Duration(f), err
 	case string:
-		if strings.ContainsAny(s, "nsuµmh") {
-			d, err = time.ParseDuration(s)
-		} else {
-			d, err = time.ParseDuration(s + "ns")
+		s = c.ascii(s)
+		if !strings.ContainsAny(s, "nsuµmh") {
+			s += "ns"
+		}
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, c.castE(i, "Duration")
 		}
 		return
 	default:
-		err = fmt.Errorf("unable to cast %#v of type %T to Duration", i, i)
+		err = c.castE(i, "Duration")
 		return
 	}
 }
 
 // BoolE casts an interface to a bool type.
 func BoolE(i interface{}) (bool, error) {
+	return std.BoolE(i)
+}
+
+// BoolE casts an interface to a bool type. Numbers are true when non-zero
+// and strings are matched against c.BoolWords and the YAML 1.1 style words
+// in boolWords, ignoring case and surrounding whitespace.
+func (c *Caster) BoolE(i interface{}) (bool, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return false, c.nilE("bool")
+	}
+
 	switch b := i.(type) {
 	case bool:
 		return b, nil
-	case nil:
-		return false, nil
 	case int:
-		if i.(int) != 0 {
-			return true, nil
+		return b != 0, nil
+	case int64:
+		return b != 0, nil
+	case int32:
+		return b != 0, nil
+	case int16:
+		return b != 0, nil
+	case int8:
+		return b != 0, nil
+	case uint:
+		return b != 0, nil
+	case uint64:
+		return b != 0, nil
+	case uint32:
+		return b != 0, nil
+	case uint16:
+		return b != 0, nil
+	case uint8:
+		return b != 0, nil
+	case float64:
+		return b != 0, nil
+	case float32:
+		return b != 0, nil
+	case json.Number:
+		f, err := b.Float64()
+		if err != nil {
+			return false, c.castE(i, "bool")
 		}
-		return false, nil
+		return f != 0, nil
 	case string:
-		return strconv.ParseBool(i.(string))
-	default:
-		return false, fmt.Errorf("unable to cast %#v of type %T to bool", i, i)
+		s := strings.TrimSpace(b)
+		for word, v := range c.BoolWords {
+			if strings.EqualFold(word, s) {
+				return v, nil
+			}
+		}
+		if v, ok := boolWords[strings.ToLower(s)]; ok {
+			return v, nil
+		}
+		return false, c.castE(i, "bool")
+	}
+
+	switch v := reflect.ValueOf(i); v.Kind() {
+	case reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8:
+		return v.Int() != 0, nil
+	case reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8, reflect.Uintptr:
+		return v.Uint() != 0, nil
+	case reflect.Float64, reflect.Float32:
+		return v.Float() != 0, nil
 	}
+	return false, c.castE(i, "bool")
 }
 
 // Float64E casts an interface to a float64 type.
 func Float64E(i interface{}) (float64, error) {
+	return std.Float64E(i)
+}
+
+// Float64E casts an interface to a float64 type.
+func (c *Caster) Float64E(i interface{}) (float64, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("float64")
+	}
+
 	switch s := i.(type) {
 	case float64:
-		return s, nil
+		return c.finite(i, s, "float64")
 	case float32:
-		return float64(s), nil
+		return c.finite(i, float64(s), "float64")
 	case int:
-		return float64(s), nil
+		return c.intFloat(i, int64(s), "float64")
 	case int64:
-		return float64(s), nil
+		return c.intFloat(i, s, "float64")
 	case int32:
 		return float64(s), nil
 	case int16:
@@ -111,9 +213,9 @@ func Float64E(i interface{}) (float64, error) {
 	case int8:
 		return float64(s), nil
 	case uint:
-		return float64(s), nil
+		return c.uintFloat(i, uint64(s), "float64")
 	case uint64:
-		return float64(s), nil
+		return c.uintFloat(i, s, "float64")
 	case uint32:
 		return float64(s), nil
 	case uint16:
@@ -121,70 +223,98 @@ func Float64E(i interface{}) (float64, error) {
 	case uint8:
 		return float64(s), nil
 	case string:
-		v, err := strconv.ParseFloat(s, 64)
+		v, err := c.parseFloat(s, 64)
 		if err == nil {
-			return v, nil
+			return c.finite(i, v, "float64")
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", i, i)
+		return 0, c.parseE(i, "float64", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float64", i, i)
+		return 0, c.castE(i, "float64")
 	}
 }
 
 // Float32E casts an interface to a float32 type.
 func Float32E(i interface{}) (float32, error) {
+	return std.Float32E(i)
+}
+
+// Float32E casts an interface to a float32 type.
+func (c *Caster) Float32E(i interface{}) (float32, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("float32")
+	}
+
 	switch s := i.(type) {
 	case float64:
-		return float32(s), nil
+		return c.narrow(i, s)
 	case float32:
-		return s, nil
+		return c.narrow(i, float64(s))
 	case int:
-		return float32(s), nil
+		v, err := c.intFloat(i, int64(s), "float32")
+		return float32(v), err
 	case int64:
-		return float32(s), nil
+		v, err := c.intFloat(i, s, "float32")
+		return float32(v), err
 	case int32:
-		return float32(s), nil
+		v, err := c.intFloat(i, int64(s), "float32")
+		return float32(v), err
 	case int16:
 		return float32(s), nil
 	case int8:
 		return float32(s), nil
 	case uint:
-		return float32(s), nil
+		v, err := c.uintFloat(i, uint64(s), "float32")
+		return float32(v), err
 	case uint64:
-		return float32(s), nil
+		v, err := c.uintFloat(i, s, "float32")
+		return float32(v), err
 	case uint32:
-		return float32(s), nil
+		v, err := c.uintFloat(i, uint64(s), "float32")
+		return float32(v), err
 	case uint16:
 		return float32(s), nil
 	case uint8:
 		return float32(s), nil
 	case string:
-		v, err := strconv.ParseFloat(s, 32)
+		bits := 32
+		if c.Exact {
+			bits = 64
+		}
+		v, err := c.parseFloat(s, bits)
 		if err == nil {
-			return float32(v), nil
+			return c.narrow(i, v)
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", i, i)
+		return 0, c.parseE(i, "float32", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to float32", i, i)
+		return 0, c.castE(i, "float32")
 	}
 }
 
 // Int64E casts an interface to an int64 type.
 func Int64E(i interface{}) (int64, error) {
+	return std.Int64E(i)
+}
+
+// Int64E casts an interface to an int64 type.
+func (c *Caster) Int64E(i interface{}) (int64, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("int64")
+	}
+
 	switch s := i.(type) {
 	case int:
 		return int64(s), nil
@@ -207,31 +337,40 @@ func Int64E(i interface{}) (int64, error) {
 	case uint8:
 		return int64(s), nil
 	case float64:
-		return int64(s), nil
+		v, err := c.floatInt(i, s, "int64", 64, true)
+		return int64(v), err
 	case float32:
-		return int64(s), nil
+		v, err := c.floatInt(i, float64(s), "int64", 64, true)
+		return int64(v), err
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return v, nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", i, i)
+		return 0, c.parseE(i, "int64", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int64", i, i)
+		return 0, c.castE(i, "int64")
 	}
 }
 
 // Int32E casts an interface to an int32 type.
 func Int32E(i interface{}) (int32, error) {
+	return std.Int32E(i)
+}
+
+// Int32E casts an interface to an int32 type.
+func (c *Caster) Int32E(i interface{}) (int32, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("int32")
+	}
+
 	switch s := i.(type) {
 	case int:
 		return int32(s), nil
@@ -254,31 +393,40 @@ func Int32E(i interface{}) (int32, error) {
 	case uint8:
 		return int32(s), nil
 	case float64:
-		return int32(s), nil
+		v, err := c.floatInt(i, s, "int32", 32, true)
+		return int32(v), err
 	case float32:
-		return int32(s), nil
+		v, err := c.floatInt(i, float64(s), "int32", 32, true)
+		return int32(v), err
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int32(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", i, i)
+		return 0, c.parseE(i, "int32", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int32", i, i)
+		return 0, c.castE(i, "int32")
 	}
 }
 
 // Int16E casts an interface to an int16 type.
 func Int16E(i interface{}) (int16, error) {
+	return std.Int16E(i)
+}
+
+// Int16E casts an interface to an int16 type.
+func (c *Caster) Int16E(i interface{}) (int16, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("int16")
+	}
+
 	switch s := i.(type) {
 	case int:
 		return int16(s), nil
@@ -301,31 +449,40 @@ func Int16E(i interface{}) (int16, error) {
 	case uint8:
 		return int16(s), nil
 	case float64:
-		return int16(s), nil
+		v, err := c.floatInt(i, s, "int16", 16, true)
+		return int16(v), err
 	case float32:
-		return int16(s), nil
+		v, err := c.floatInt(i, float64(s), "int16", 16, true)
+		return int16(v), err
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int16(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", i, i)
+		return 0, c.parseE(i, "int16", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int16", i, i)
+		return 0, c.castE(i, "int16")
 	}
 }
 
 // Int8E casts an interface to an int8 type.
 func Int8E(i interface{}) (int8, error) {
+	return std.Int8E(i)
+}
+
+// Int8E casts an interface to an int8 type.
+func (c *Caster) Int8E(i interface{}) (int8, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("int8")
+	}
+
 	switch s := i.(type) {
 	case int:
 		return int8(s), nil
@@ -348,31 +505,40 @@ func Int8E(i interface{}) (int8, error) {
 	case uint8:
 		return int8(s), nil
 	case float64:
-		return int8(s), nil
+		v, err := c.floatInt(i, s, "int8", 8, true)
+		return int8(v), err
 	case float32:
-		return int8(s), nil
+		v, err := c.floatInt(i, float64(s), "int8", 8, true)
+		return int8(v), err
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int8(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", i, i)
+		return 0, c.parseE(i, "int8", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int8", i, i)
+		return 0, c.castE(i, "int8")
 	}
 }
 
 // IntE casts an interface to an int type.
 func IntE(i interface{}) (int, error) {
+	return std.IntE(i)
+}
+
+// IntE casts an interface to an int type.
+func (c *Caster) IntE(i interface{}) (int, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("int")
+	}
+
 	switch s := i.(type) {
 	case int:
 		return s, nil
@@ -395,38 +561,47 @@ func IntE(i interface{}) (int, error) {
 	case uint8:
 		return int(s), nil
 	case float64:
-		return int(s), nil
+		v, err := c.floatInt(i, s, "int", strconv.IntSize, true)
+		return int(v), err
 	case float32:
-		return int(s), nil
+		v, err := c.floatInt(i, float64(s), "int", strconv.IntSize, true)
+		return int(v), err
 	case string:
-		v, err := strconv.ParseInt(s, 0, 0)
+		v, err := c.parseInt(s, 0)
 		if err == nil {
 			return int(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", i, i)
+		return 0, c.parseE(i, "int", err)
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to int", i, i)
+		return 0, c.castE(i, "int")
 	}
 }
 
 // UintE casts an interface to a uint type.
 func UintE(i interface{}) (uint, error) {
+	return std.UintE(i)
+}
+
+// UintE casts an interface to a uint type.
+func (c *Caster) UintE(i interface{}) (uint, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("uint")
+	}
+
 	switch s := i.(type) {
 	case string:
-		v, err := strconv.ParseUint(s, 0, 0)
+		v, err := c.parseUint(s, 0)
 		if err == nil {
 			return uint(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint: %s", i, err)
+		return 0, c.parseE(i, "uint", err)
 	case int:
 		if s < 0 {
 			return 0, errNegativeNotAllowed
@@ -463,38 +638,41 @@ func UintE(i interface{}) (uint, error) {
 	case uint8:
 		return uint(s), nil
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		v, err := c.floatUint(i, s, "uint", strconv.IntSize)
+		return uint(v), err
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint(s), nil
+		v, err := c.floatUint(i, float64(s), "uint", strconv.IntSize)
+		return uint(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint", i, i)
+		return 0, c.castE(i, "uint")
 	}
 }
 
 // Uint64E casts an interface to a uint64 type.
 func Uint64E(i interface{}) (uint64, error) {
+	return std.Uint64E(i)
+}
+
+// Uint64E casts an interface to a uint64 type.
+func (c *Caster) Uint64E(i interface{}) (uint64, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("uint64")
+	}
+
 	switch s := i.(type) {
 	case string:
-		v, err := strconv.ParseUint(s, 0, 64)
+		v, err := c.parseUint(s, 64)
 		if err == nil {
 			return v, nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint64: %s", i, err)
+		return 0, c.parseE(i, "uint64", err)
 	case int:
 		if s < 0 {
 			return 0, errNegativeNotAllowed
@@ -531,38 +709,41 @@ func Uint64E(i interface{}) (uint64, error) {
 	case uint8:
 		return uint64(s), nil
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
+		v, err := c.floatUint(i, float64(s), "uint64", 64)
+		return uint64(v), err
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint64(s), nil
+		v, err := c.floatUint(i, s, "uint64", 64)
+		return uint64(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint64", i, i)
+		return 0, c.castE(i, "uint64")
 	}
 }
 
 // Uint32E casts an interface to a uint32 type.
 func Uint32E(i interface{}) (uint32, error) {
+	return std.Uint32E(i)
+}
+
+// Uint32E casts an interface to a uint32 type.
+func (c *Caster) Uint32E(i interface{}) (uint32, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("uint32")
+	}
+
 	switch s := i.(type) {
 	case string:
-		v, err := strconv.ParseUint(s, 0, 32)
+		v, err := c.parseUint(s, 32)
 		if err == nil {
 			return uint32(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint32: %s", i, err)
+		return 0, c.parseE(i, "uint32", err)
 	case int:
 		if s < 0 {
 			return 0, errNegativeNotAllowed
@@ -599,38 +780,41 @@ func Uint32E(i interface{}) (uint32, error) {
 	case uint8:
 		return uint32(s), nil
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
+		v, err := c.floatUint(i, s, "uint32", 32)
+		return uint32(v), err
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint32(s), nil
+		v, err := c.floatUint(i, float64(s), "uint32", 32)
+		return uint32(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint32", i, i)
+		return 0, c.castE(i, "uint32")
 	}
 }
 
 // Uint16E casts an interface to a uint16 type.
 func Uint16E(i interface{}) (uint16, error) {
+	return std.Uint16E(i)
+}
+
+// Uint16E casts an interface to a uint16 type.
+func (c *Caster) Uint16E(i interface{}) (uint16, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("uint16")
+	}
+
 	switch s := i.(type) {
 	case string:
-		v, err := strconv.ParseUint(s, 0, 16)
+		v, err := c.parseUint(s, 16)
 		if err == nil {
 			return uint16(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint16: %s", i, err)
+		return 0, c.parseE(i, "uint16", err)
 	case int:
 		if s < 0 {
 			return 0, errNegativeNotAllowed
@@ -667,38 +851,41 @@ func Uint16E(i interface{}) (uint16, error) {
 	case uint8:
 		return uint16(s), nil
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
+		v, err := c.floatUint(i, s, "uint16", 16)
+		return uint16(v), err
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint16(s), nil
+		v, err := c.floatUint(i, float64(s), "uint16", 16)
+		return uint16(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint16", i, i)
+		return 0, c.castE(i, "uint16")
 	}
 }
 
 // Uint8E casts an interface to a uint type.
 func Uint8E(i interface{}) (uint8, error) {
+	return std.Uint8E(i)
+}
+
+// Uint8E casts an interface to a uint type.
+func (c *Caster) Uint8E(i interface{}) (uint8, error) {
 	i = indirect(i)
 
+	if isNil(i) {
+		return 0, c.nilE("uint8")
+	}
+
 	switch s := i.(type) {
 	case string:
-		v, err := strconv.ParseUint(s, 0, 8)
+		v, err := c.parseUint(s, 8)
 		if err == nil {
 			return uint8(v), nil
 		}
-		return 0, fmt.Errorf("unable to cast %#v to uint8: %s", i, err)
+		return 0, c.parseE(i, "uint8", err)
 	case int:
 		if s < 0 {
 			return 0, errNegativeNotAllowed
@@ -735,24 +922,18 @@ func Uint8E(i interface{}) (uint8, error) {
 	case uint8:
 		return s, nil
 	case float64:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
+		v, err := c.floatUint(i, s, "uint8", 8)
+		return uint8(v), err
 	case float32:
-		if s < 0 {
-			return 0, errNegativeNotAllowed
-		}
-		return uint8(s), nil
+		v, err := c.floatUint(i, float64(s), "uint8", 8)
+		return uint8(v), err
 	case bool:
 		if s {
 			return 1, nil
 		}
 		return 0, nil
-	case nil:
-		return 0, nil
 	default:
-		return 0, fmt.Errorf("unable to cast %#v of type %T to uint8", i, i)
+		return 0, c.castE(i, "uint8")
 	}
 }
 
@@ -797,8 +978,29 @@ func indirectToStringerOrError(a interface{}) interface{} {
 
 // StringE casts an interface to a string type.
 func StringE(i interface{}) (string, error) {
+	return std.StringE(i)
+}
+
+// StringE casts an interface to a string type, writing integers in
+// c.IntFormat and numbers in c.NumberFormat when they are set.
+func (c *Caster) StringE(i interface{}) (string, error) {
 	i = indirectToStringerOrError(i)
 
+	if isNil(i) {
+		return "", c.nilE("string")
+	}
+
+	if c.IntFormat != nil {
+		if s, ok := c.IntFormat.format(i); ok {
+			return s, nil
+		}
+	}
+	if c.NumberFormat != nil {
+		if s, ok := c.NumberFormat.format(i); ok {
+			return s, nil
+		}
+	}
+
 	switch s := i.(type) {
 	case string:
 		return s, nil
@@ -840,239 +1042,249 @@ func StringE(i interface{}) (string, error) {
 		return string(s), nil
 	case template.HTMLAttr:
 		return string(s), nil
-	case nil:
-		return "", nil
 	case fmt.Stringer:
 		return s.String(), nil
 	case error:
 		return s.Error(), nil
 	default:
-		return "", fmt.Errorf("unable to cast %#v of type %T to string", i, i)
+		return "", c.castE(i, "string")
 	}
 }
 
 // StringMapStringE casts an interface to a map[string]string type.
 func StringMapStringE(i interface{}) (map[string]string, error) {
+	return std.StringMapStringE(i)
+}
+
+// StringMapStringE casts an interface to a map[string]string type.
+func (c *Caster) StringMapStringE(i interface{}) (map[string]string, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string]string")
+	}
+	if pm, ok, err := c.pairsE(i, false); ok {
+		if err != nil {
+			return nil, c.castEf(i, "map[string]string", "%w", err)
+		}
+		return c.StringMapStringE(pm)
+	}
+
 	var m = map[string]string{}
 
 	switch v := i.(type) {
 	case map[string]string:
 		return v, nil
-	case map[string]interface{}:
-		for k, val := range v {
-			m[String(k)] = String(val)
-		}
-		return m, nil
-	case map[interface{}]string:
-		for k, val := range v {
-			m[String(k)] = String(val)
-		}
-		return m, nil
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			m[String(k)] = String(val)
-		}
-		return m, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
-	default:
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]string", i, i)
 	}
+
+	if reflect.TypeOf(i).Kind() != reflect.Map {
+		return m, c.castE(i, "map[string]string")
+	}
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return c.StringE(e)
+	})
 }
 
 // StringMapStringSliceE casts an interface to a map[string][]string type.
 func StringMapStringSliceE(i interface{}) (map[string][]string, error) {
+	return std.StringMapStringSliceE(i)
+}
+
+// StringMapStringSliceE casts an interface to a map[string][]string type.
+func (c *Caster) StringMapStringSliceE(i interface{}) (map[string][]string, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string][]string")
+	}
+	if pm, ok, err := c.pairsE(i, true); ok {
+		if err != nil {
+			return nil, c.castEf(i, "map[string][]string", "%w", err)
+		}
+		return c.StringMapStringSliceE(pm)
+	}
+
 	var m = map[string][]string{}
 
 	switch v := i.(type) {
 	case map[string][]string:
 		return v, nil
-	case map[string][]interface{}:
-		for k, val := range v {
-			m[String(k)] = StringSlice(val)
-		}
-		return m, nil
-	case map[string]string:
-		for k, val := range v {
-			m[String(k)] = []string{val}
-		}
-	case map[string]interface{}:
-		for k, val := range v {
-			switch vt := val.(type) {
-			case []interface{}:
-				m[String(k)] = StringSlice(vt)
-			case []string:
-				m[String(k)] = vt
-			default:
-				m[String(k)] = []string{String(val)}
-			}
-		}
-		return m, nil
-	case map[interface{}][]string:
-		for k, val := range v {
-			m[String(k)] = StringSlice(val)
-		}
-		return m, nil
-	case map[interface{}]string:
-		for k, val := range v {
-			m[String(k)] = StringSlice(val)
-		}
-		return m, nil
-	case map[interface{}][]interface{}:
-		for k, val := range v {
-			m[String(k)] = StringSlice(val)
-		}
-		return m, nil
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			key, err := StringE(k)
-			if err != nil {
-				return m, fmt.Errorf("unable to cast %#v of type %T to map[string][]string", i, i)
+	case map[string]string, map[string]interface{}:
+		// Plain string values are taken whole, not split.
+		return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+			switch e.(type) {
+			case []interface{}, []string:
+				return c.StringSliceE(e)
 			}
-			value, err := StringSliceE(val)
+			str, err := c.StringE(e)
 			if err != nil {
-				return m, fmt.Errorf("unable to cast %#v of type %T to map[string][]string", i, i)
+				return nil, err
 			}
-			m[key] = value
-		}
+			return []string{str}, nil
+		})
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
-	default:
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string][]string", i, i)
 	}
-	return m, nil
+
+	if reflect.TypeOf(i).Kind() != reflect.Map {
+		return m, c.castE(i, "map[string][]string")
+	}
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return c.StringSliceE(e)
+	})
 }
 
 // StringMapBoolE casts an interface to a map[string]bool type.
 func StringMapBoolE(i interface{}) (map[string]bool, error) {
+	return std.StringMapBoolE(i)
+}
+
+// StringMapBoolE casts an interface to a map[string]bool type.
+func (c *Caster) StringMapBoolE(i interface{}) (map[string]bool, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string]bool")
+	}
+	if pm, ok, err := c.pairsE(i, false); ok {
+		if err != nil {
+			return nil, c.castEf(i, "map[string]bool", "%w", err)
+		}
+		return c.StringMapBoolE(pm)
+	}
+
 	var m = map[string]bool{}
 
 	switch v := i.(type) {
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			m[String(k)] = Bool(val)
-		}
-		return m, nil
-	case map[string]interface{}:
-		for k, val := range v {
-			m[String(k)] = Bool(val)
-		}
-		return m, nil
 	case map[string]bool:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
-	default:
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]bool", i, i)
 	}
+
+	if reflect.TypeOf(i).Kind() != reflect.Map {
+		return m, c.castE(i, "map[string]bool")
+	}
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return c.BoolE(e)
+	})
 }
 
 // StringMapE casts an interface to a map[string]interface{} type.
 func StringMapE(i interface{}) (map[string]interface{}, error) {
+	return std.StringMapE(i)
+}
+
+// StringMapE casts an interface to a map[string]interface{} type.
+func (c *Caster) StringMapE(i interface{}) (map[string]interface{}, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string]interface{}")
+	}
+	if pm, ok, err := c.pairsE(i, false); ok {
+		if err != nil {
+			return nil, c.castEf(i, "map[string]interface{}", "%w", err)
+		}
+		return c.StringMapE(pm)
+	}
+
 	var m = map[string]interface{}{}
 
 	switch v := i.(type) {
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			m[String(k)] = val
-		}
-		return m, nil
 	case map[string]interface{}:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
-	default:
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]interface{}", i, i)
 	}
+
+	if reflect.TypeOf(i).Kind() != reflect.Map {
+		return m, c.castE(i, "map[string]interface{}")
+	}
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return e, nil
+	})
 }
 
 // StringMapIntE casts an interface to a map[string]int{} type.
 func StringMapIntE(i interface{}) (map[string]int, error) {
-	var m = map[string]int{}
-	if i == nil {
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int", i, i)
+	return std.StringMapIntE(i)
+}
+
+// StringMapIntE casts an interface to a map[string]int{} type.
+func (c *Caster) StringMapIntE(i interface{}) (map[string]int, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string]int")
+	}
+	if pm, ok, err := c.pairsE(i, false); ok {
+		if err != nil {
+			return nil, c.castEf(i, "map[string]int", "%w", err)
+		}
+		return c.StringMapIntE(pm)
 	}
 
+	var m = map[string]int{}
+
 	switch v := i.(type) {
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			m[String(k)] = Int(val)
-		}
-		return m, nil
-	case map[string]interface{}:
-		for k, val := range v {
-			m[k] = Int(val)
-		}
-		return m, nil
 	case map[string]int:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	}
 
 	if reflect.TypeOf(i).Kind() != reflect.Map {
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int", i, i)
+		return m, c.castE(i, "map[string]int")
 	}
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return c.IntE(e)
+	})
+}
+
+// StringMapInt64E casts an interface to a map[string]int64{} type.
+func StringMapInt64E(i interface{}) (map[string]int64, error) {
+	return std.StringMapInt64E(i)
+}
 
-	mVal := reflect.ValueOf(m)
-	v := reflect.ValueOf(i)
-	for _, keyVal := range v.MapKeys() {
-		val, err := IntE(v.MapIndex(keyVal).Interface())
+// StringMapInt64E casts an interface to a map[string]int64{} type.
+func (c *Caster) StringMapInt64E(i interface{}) (map[string]int64, error) {
+	if isNil(i) {
+		return nil, c.nilE("map[string]int64")
+	}
+	if pm, ok, err := c.pairsE(i, false); ok {
 		if err != nil {
-			return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int", i, i)
+			return nil, c.castEf(i, "map[string]int64", "%w", err)
 		}
-		mVal.SetMapIndex(keyVal, reflect.ValueOf(val))
+		return c.StringMapInt64E(pm)
 	}
-	return m, nil
-}
 
-// StringMapInt64E casts an interface to a map[string]int64{} type.
-func StringMapInt64E(i interface{}) (map[string]int64, error) {
 	var m = map[string]int64{}
-	if i == nil {
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int64", i, i)
-	}
 
 	switch v := i.(type) {
-	case map[interface{}]interface{}:
-		for k, val := range v {
-			m[String(k)] = Int64(val)
-		}
-		return m, nil
-	case map[string]interface{}:
-		for k, val := range v {
-			m[k] = Int64(val)
-		}
-		return m, nil
 	case map[string]int64:
 		return v, nil
 	case string:
-		err := jsonStringToObject(v, &m)
+		err := c.jsonStringToObject(v, &m)
 		return m, err
 	}
 
 	if reflect.TypeOf(i).Kind() != reflect.Map {
-		return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int64", i, i)
-	}
-	mVal := reflect.ValueOf(m)
-	v := reflect.ValueOf(i)
-	for _, keyVal := range v.MapKeys() {
-		val, err := Int64E(v.MapIndex(keyVal).Interface())
-		if err != nil {
-			return m, fmt.Errorf("unable to cast %#v of type %T to map[string]int64", i, i)
-		}
-		mVal.SetMapIndex(keyVal, reflect.ValueOf(val))
+		return m, c.castE(i, "map[string]int64")
 	}
-	return m, nil
+	return m, c.stringMapE(i, m, func(e interface{}) (interface{}, error) {
+		return c.Int64E(e)
+	})
 }
 
 // SliceE casts an interface to a []interface{} type.
 func SliceE(i interface{}) ([]interface{}, error) {
+	return std.SliceE(i)
+}
+
+// SliceE casts an interface to a []interface{} type.
+func (c *Caster) SliceE(i interface{}) ([]interface{}, error) {
+	if isNil(i) {
+		return nil, c.nilE("[]interface{}")
+	}
+
 	var s []interface{}
 
 	switch v := i.(type) {
@@ -1083,121 +1295,88 @@ func SliceE(i interface{}) ([]interface{}, error) {
 			s = append(s, u)
 		}
 		return s, nil
+	case string:
+		return c.splitList(v)
 	default:
-		return s, fmt.Errorf("unable to cast %#v of type %T to []interface{}", i, i)
+		return s, c.castE(i, "[]interface{}")
 	}
 }
 
 // BoolSliceE casts an interface to a []bool type.
 func BoolSliceE(i interface{}) ([]bool, error) {
-	if i == nil {
-		return []bool{}, fmt.Errorf("unable to cast %#v of type %T to []bool", i, i)
-	}
-
-	switch v := i.(type) {
-	case []bool:
-		return v, nil
-	}
+	return std.BoolSliceE(i)
+}
 
-	kind := reflect.TypeOf(i).Kind()
-	switch kind {
-	case reflect.Slice, reflect.Array:
-		s := reflect.ValueOf(i)
-		a := make([]bool, s.Len())
-		for j := 0; j < s.Len(); j++ {
-			val, err := BoolE(s.Index(j).Interface())
-			if err != nil {
-				return []bool{}, fmt.Errorf("unable to cast %#v of type %T to []bool", i, i)
-			}
-			a[j] = val
-		}
-		return a, nil
-	default:
-		return []bool{}, fmt.Errorf("unable to cast %#v of type %T to []bool", i, i)
-	}
+// BoolSliceE casts an interface to a []bool type.
+func (c *Caster) BoolSliceE(i interface{}) ([]bool, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]bool(nil)), func(e interface{}) (interface{}, error) {
+		return c.BoolE(e)
+	})
+	return v.([]bool), err
 }
 
 // StringSliceE casts an interface to a []string type.
 func StringSliceE(i interface{}) ([]string, error) {
+	return std.StringSliceE(i)
+}
+
+// StringSliceE casts an interface to a []string type.
+func (c *Caster) StringSliceE(i interface{}) ([]string, error) {
+	if isNil(i) {
+		return nil, c.nilE("[]string")
+	}
+
 	var a []string
 
 	switch v := i.(type) {
 	case []interface{}:
-		for _, u := range v {
-			a = append(a, String(u))
-		}
-		return a, nil
+		l, err := c.sliceE(v, reflect.TypeOf([]string(nil)), func(e interface{}) (interface{}, error) {
+			return c.StringE(e)
+		})
+		return l.([]string), err
 	case []string:
 		return v, nil
 	case string:
-		return strings.Fields(v), nil
+		l, err := c.splitList(v)
+		if err != nil {
+			return a, c.castEf(i, "[]string", "%w", err)
+		}
+		return c.StringSliceE(l)
 	case interface{}:
-		str, err := StringE(v)
+		str, err := c.StringE(v)
 		if err != nil {
-			return a, fmt.Errorf("unable to cast %#v of type %T to []string", i, i)
+			return a, c.castE(i, "[]string")
 		}
 		return []string{str}, nil
 	default:
-		return a, fmt.Errorf("unable to cast %#v of type %T to []string", i, i)
+		return a, c.castE(i, "[]string")
 	}
 }
 
 // IntSliceE casts an interface to a []int type.
 func IntSliceE(i interface{}) ([]int, error) {
-	if i == nil {
-		return []int{}, fmt.Errorf("unable to cast %#v of type %T to []int", i, i)
-	}
-
-	switch v := i.(type) {
-	case []int:
-		return v, nil
-	}
+	return std.IntSliceE(i)
+}
 
-	kind := reflect.TypeOf(i).Kind()
-	switch kind {
-	case reflect.Slice, reflect.Array:
-		s := reflect.ValueOf(i)
-		a := make([]int, s.Len())
-		for j := 0; j < s.Len(); j++ {
-			val, err := IntE(s.Index(j).Interface())
-			if err != nil {
-				return []int{}, fmt.Errorf("unable to cast %#v of type %T to []int", i, i)
-			}
-			a[j] = val
-		}
-		return a, nil
-	default:
-		return []int{}, fmt.Errorf("unable to cast %#v of type %T to []int", i, i)
-	}
+// IntSliceE casts an interface to a []int type.
+func (c *Caster) IntSliceE(i interface{}) ([]int, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]int(nil)), func(e interface{}) (interface{}, error) {
+		return c.IntE(e)
+	})
+	return v.([]int), err
 }
 
 // DurationSliceE casts an interface to a []time.Duration type.
 func DurationSliceE(i interface{}) ([]time.Duration, error) {
-	if i == nil {
-		return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration", i, i)
-	}
-
-	switch v := i.(type) {
-	case []time.Duration:
-		return v, nil
-	}
+	return std.DurationSliceE(i)
+}
 
-	kind := reflect.TypeOf(i).Kind()
-	switch kind {
-	case reflect.Slice, reflect.Array:
-		s := reflect.ValueOf(i)
-		a := make([]time.Duration, s.Len())
-		for j := 0; j < s.Len(); j++ {
-			val, err := DurationE(s.Index(j).Interface())
-			if err != nil {
-				return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration", i, i)
-			}
-			a[j] = val
-		}
-		return a, nil
-	default:
-		return []time.Duration{}, fmt.Errorf("unable to cast %#v of type %T to []time.Duration", i, i)
-	}
+// DurationSliceE casts an interface to a []time.Duration type.
+func (c *Caster) DurationSliceE(i interface{}) ([]time.Duration, error) {
+	v, err := c.sliceE(i, reflect.TypeOf([]time.Duration(nil)), func(e interface{}) (interface{}, error) {
+		return c.DurationE(e)
+	})
+	return v.([]time.Duration), err
 }
 
 // StringToDate attempts to parse a string into a time.Time type using a
@@ -1240,10 +1419,3 @@ func parseDateWith(s string, dates []string) (d time.Time, e error) {
 	}
 	return d, fmt.Errorf("unable to parse date: %s", s)
 }
-
-// jsonStringToObject attempts to unmarshall a string as JSON into
-// the object passed as pointer.
-func jsonStringToObject(s string, v interface{}) error {
-	data := []byte(s)
-	return json.Unmarshal(data, v)
-}
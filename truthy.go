@@ -0,0 +1,115 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Truthiness selects the rules TruthyE follows. Under every policy a nil
+// value is false, and so is a value whose IsZero() bool method reports
+// true, as time.Time has.
+type Truthiness int
+
+const (
+	// TruthGo follows the if action of text/template: false, zero numbers,
+	// empty strings, arrays, slices and maps, and nil pointers, interfaces,
+	// channels and funcs are false. Everything else, structs included, is
+	// true.
+	TruthGo Truthiness = iota
+	// TruthJS follows JavaScript: false, zero numbers, NaN, empty strings
+	// and nil are false. Empty arrays, slices, maps and structs are
+	// objects and so are true.
+	TruthJS
+	// TruthPython follows Python: like TruthGo, but a value with a
+	// Len() int method is false when its length is zero.
+	TruthPython
+)
+
+type isZeroer interface {
+	IsZero() bool
+}
+
+type lener interface {
+	Len() int
+}
+
+func (t Truthiness) String() string {
+	switch t {
+	case TruthGo:
+		return "Go"
+	case TruthJS:
+		return "JavaScript"
+	case TruthPython:
+		return "Python"
+	default:
+		return fmt.Sprintf("Truthiness(%d)", int(t))
+	}
+}
+
+// TruthyE reports whether an interface is truthy under the text/template
+// rules.
+func TruthyE(i interface{}) (bool, error) {
+	return std.TruthyE(i)
+}
+
+// TruthyE reports whether an interface is truthy under c.Truthiness.
+func (c *Caster) TruthyE(i interface{}) (bool, error) {
+	switch c.Truthiness {
+	case TruthGo, TruthJS, TruthPython:
+		return truth(reflect.ValueOf(i), c.Truthiness), nil
+	default:
+		return false, fmt.Errorf("unknown truthiness %s", c.Truthiness)
+	}
+}
+
+func truth(v reflect.Value, t Truthiness) bool {
+	if !v.IsValid() {
+		return false
+	}
+
+	nilPtr := v.Kind() == reflect.Ptr && v.IsNil()
+	if v.CanInterface() && !nilPtr {
+		if z, ok := v.Interface().(isZeroer); ok {
+			return !z.IsZero()
+		}
+		if l, ok := v.Interface().(lener); ok && t == TruthPython {
+			return l.Len() > 0
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if t == TruthJS && math.IsNaN(f) {
+			return false
+		}
+		return f != 0
+	case reflect.Complex64, reflect.Complex128:
+		return v.Complex() != 0
+	case reflect.String:
+		return v.Len() > 0
+	case reflect.Array:
+		return t == TruthJS || v.Len() > 0
+	case reflect.Slice, reflect.Map:
+		if t == TruthJS {
+			return !v.IsNil()
+		}
+		return v.Len() > 0
+	case reflect.Ptr, reflect.Interface, reflect.Chan, reflect.Func:
+		return !v.IsNil()
+	default:
+		return true
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright © 2014 Steve Francia <spf@spf13.com>.
+//
+// Use of this source code is governed by an MIT-style
+// license that can be found in the LICENSE file.
+
+package to
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type truthyLen []int
+
+func (l truthyLen) Len() int { return 0 }
+
+func TestTruthyE(t *testing.T) {
+	var nilPtr *int
+	var nilSlice []int
+	var nilTime *time.Time
+	zero := 0
+
+	tests := []struct {
+		input  interface{}
+		goTpl  bool
+		js     bool
+		python bool
+	}{
+		{nil, false, false, false},
+		{false, false, false, false},
+		{true, true, true, true},
+		{0, false, false, false},
+		{int8(-3), true, true, true},
+		{uint64(0), false, false, false},
+		{0.0, false, false, false},
+		{math.NaN(), true, false, true},
+		{"", false, false, false},
+		{"0", true, true, true},
+		{"false", true, true, true},
+		{nilPtr, false, false, false},
+		{&zero, true, true, true},
+		{nilSlice, false, false, false},
+		{[]int{}, false, true, false},
+		{[]int{0}, true, true, true},
+		{map[string]int{}, false, true, false},
+		{[0]int{}, false, true, false},
+		{struct{}{}, true, true, true},
+		{time.Time{}, false, false, false},
+		{time.Now(), true, true, true},
+		{nilTime, false, false, false},
+		{truthyLen{1}, true, true, false},
+	}
+
+	policies := []*Caster{{Truthiness: TruthGo}, {Truthiness: TruthJS}, {Truthiness: TruthPython}}
+	for i, test := range tests {
+		for n, expect := range []bool{test.goTpl, test.js, test.python} {
+			c := policies[n]
+			errmsg := fmt.Sprintf("i = %d, %s", i, c.Truthiness) // assert helper message
+
+			v, err := c.TruthyE(test.input)
+			assert.NoError(t, err, errmsg)
+			assert.Equal(t, expect, v, errmsg)
+		}
+
+		// Non-E test
+		assert.Equal(t, test.goTpl, Truthy(test.input), fmt.Sprintf("i = %d", i))
+	}
+
+	_, err := (&Caster{Truthiness: Truthiness(42)}).TruthyE(1)
+	assert.Error(t, err)
+}